@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/AsaHero/whereismycity/internal/app"
+	"github.com/AsaHero/whereismycity/internal/service/importer"
+	"github.com/AsaHero/whereismycity/internal/service/indexer"
 	"github.com/AsaHero/whereismycity/pkg/config"
 	"github.com/AsaHero/whereismycity/pkg/logger"
 	"github.com/joho/godotenv"
@@ -17,6 +22,11 @@ func main() {
 
 	cfg := config.New()
 
+	if len(os.Args) > 1 {
+		runCommand(cfg, os.Args[1], os.Args[2:])
+		return
+	}
+
 	app, err := app.New(cfg)
 	if err != nil {
 		log.Fatalf("failed to init app: %v", err)
@@ -38,3 +48,62 @@ func main() {
 
 	app.Stop()
 }
+
+// runCommand runs a one-off subcommand instead of the API server
+func runCommand(cfg *config.Config, name string, args []string) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch name {
+	case "import":
+		flags := flag.NewFlagSet("import", flag.ExitOnError)
+		geonamesFiles := flags.String("geonames", "", "comma separated cities*.txt or allCountries.txt files")
+		alternateNames := flags.String("alternate-names", "", "alternateNamesV2.txt file")
+		admin1Codes := flags.String("admin1", "", "admin1CodesASCII.txt file used for state names")
+		countryInfo := flags.String("countries", "", "countryInfo.txt file used for country names")
+		featureClasses := flags.String("feature-classes", "P", "comma separated GeoNames feature classes to import")
+		batchSize := flags.Int("batch", 1000, "rows per transaction")
+		flags.Parse(args)
+
+		err := app.Import(ctx, cfg, importer.Options{
+			GeonamesFiles:      splitList(*geonamesFiles),
+			AlternateNamesFile: *alternateNames,
+			Admin1CodesFile:    *admin1Codes,
+			CountryInfoFile:    *countryInfo,
+			FeatureClasses:     splitList(*featureClasses),
+			BatchSize:          *batchSize,
+		})
+		if err != nil {
+			log.Fatalf("import failed: %v", err)
+		}
+	case "reindex":
+		flags := flag.NewFlagSet("reindex", flag.ExitOnError)
+		fromID := flags.Int64("from-id", 0, "index locations with a greater id, overrides the state file")
+		stateFile := flags.String("state", ".reindex_state", "file keeping the last indexed id to resume an interrupted run from, empty to disable")
+		recreate := flags.Bool("recreate", false, "drop and recreate the collection before indexing")
+		batchSize := flags.Int("batch", 200, "locations per embeddings and import request")
+		flags.Parse(args)
+
+		err := app.Reindex(ctx, cfg, indexer.Options{
+			FromID:    *fromID,
+			StateFile: *stateFile,
+			Recreate:  *recreate,
+			BatchSize: *batchSize,
+		})
+		if err != nil {
+			log.Fatalf("reindex failed: %v", err)
+		}
+	default:
+		log.Fatalf("unknown command %q", name)
+	}
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package converters
+
+import (
+	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+func APIKeyEntityToDTO(k *entity.APIKeys) models.APIKey {
+	scopes := make([]string, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return models.APIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+		ExpiresAt:  k.ExpiresAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+func APIKeysEntityToDTO(keys []*entity.APIKeys) []models.APIKey {
+	result := make([]models.APIKey, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, APIKeyEntityToDTO(k))
+	}
+	return result
+}
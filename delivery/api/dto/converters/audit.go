@@ -0,0 +1,40 @@
+package converters
+
+import (
+	"encoding/json"
+
+	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+func AuditLogEntityToDTO(l *entity.AuditLogs) models.AuditLog {
+	log := models.AuditLog{
+		ID:         l.ID,
+		ActorID:    l.ActorID,
+		ActorRole:  l.ActorRole,
+		Action:     string(l.Action),
+		TargetType: l.TargetType,
+		TargetID:   l.TargetID,
+		IP:         l.IP,
+		UserAgent:  l.UserAgent,
+		CreatedAt:  l.CreatedAt,
+	}
+
+	if l.Changes != nil {
+		log.Changes = json.RawMessage(*l.Changes)
+	}
+
+	if l.Metadata != nil {
+		log.Metadata = json.RawMessage(*l.Metadata)
+	}
+
+	return log
+}
+
+func AuditLogsEntityToDTO(logs []*entity.AuditLogs) []models.AuditLog {
+	result := make([]models.AuditLog, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, AuditLogEntityToDTO(l))
+	}
+	return result
+}
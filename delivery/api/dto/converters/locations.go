@@ -1,27 +1,271 @@
 package converters
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
 	"github.com/AsaHero/whereismycity/internal/entity"
 )
 
-func LocationEntityToDTO(locations []*entity.Locations) *models.SearchResponse {
-	response := &models.SearchResponse{
-		Locations: make([]*models.Location, 0, len(locations)),
+func LocationEntityToDTO(result *entity.SearchResult) *models.SearchResponse {
+	return &models.SearchResponse{
+		Locations:       LocationsEntityToLocationsDTO(result.Locations),
+		Degraded:        result.Degraded,
+		DegradedReasons: DegradedReasonsEntityToDTO(result.DegradedReasons),
+		Explain:         SearchExplanationEntityToDTO(result.Explanation),
+	}
+}
+
+func SearchExplanationEntityToDTO(e *entity.SearchExplanation) *models.SearchExplanation {
+	if e == nil {
+		return nil
+	}
+
+	result := &models.SearchExplanation{
+		NormalizedQuery: e.NormalizedQuery,
+		Transliteration: e.Transliteration,
+		Strategy:        string(e.Strategy),
+		Subqueries:      make([]models.SubqueryExplanation, 0, len(e.Subqueries)),
+		Merges:          make([]models.MergeExplanation, 0, len(e.Merges)),
+		Scores:          make([]models.ScoreExplanation, 0, len(e.Scores)),
+	}
+
+	for _, sub := range e.Subqueries {
+		subquery := models.SubqueryExplanation{
+			Query: sub.Query,
+			Error: sub.Error,
+			Hits:  make([]models.HitExplanation, 0, len(sub.Hits)),
+		}
+		for _, hit := range sub.Hits {
+			subquery.Hits = append(subquery.Hits, models.HitExplanation{
+				LocationID:      hit.LocationID,
+				City:            hit.City,
+				Position:        hit.Position,
+				VectorDistance:  hit.VectorDistance,
+				TextMatchScore:  hit.TextMatchScore,
+				RankFusionScore: hit.RankFusionScore,
+			})
+		}
+		result.Subqueries = append(result.Subqueries, subquery)
+	}
+
+	for _, merge := range e.Merges {
+		result.Merges = append(result.Merges, models.MergeExplanation{
+			LocationID: merge.LocationID,
+			Subquery:   merge.Subquery,
+			Position:   merge.Position,
+			Action:     merge.Action,
+			Updated:    merge.Updated,
+		})
+	}
+
+	for _, score := range e.Scores {
+		result.Scores = append(result.Scores, models.ScoreExplanation{
+			LocationID: score.LocationID,
+			City:       score.City,
+			Position:   score.Position,
+			Score:      score.Score,
+			Returned:   score.Returned,
+		})
+	}
+
+	return result
+}
+
+func DegradedReasonsEntityToDTO(reasons []entity.DegradedReason) []string {
+	if len(reasons) == 0 {
+		return nil
 	}
 
+	result := make([]string, len(reasons))
+	for i, r := range reasons {
+		result[i] = string(r)
+	}
+	return result
+}
+
+func LocationEntityToLocationDTO(l *entity.Locations) *models.Location {
+	return &models.Location{
+		ID:               l.ID,
+		City:             l.City,
+		State:            l.State,
+		Country:          l.Country,
+		Latitude:         l.Lat,
+		Longitude:        l.Lng,
+		LocalizedCity:    l.LocalizedCity,
+		Language:         l.LocalizedLanguage,
+		LocalizedState:   l.LocalizedState,
+		LocalizedCountry: l.LocalizedCountry,
+		VectorDistance:   l.VectorDistance,
+		TextMatchScore:   l.TextMatchScore,
+		RankFusionScore:  l.RankFusionScore,
+		Score:            l.Score,
+		DistanceMeters:   l.DistanceMeters,
+	}
+}
+
+func LocationsEntityToLocationsDTO(locations []*entity.Locations) []*models.Location {
+	result := make([]*models.Location, 0, len(locations))
 	for _, l := range locations {
-		response.Locations = append(response.Locations, &models.Location{
-			ID:              l.ID,
-			City:            l.City,
-			State:           l.State,
-			Country:         l.Country,
-			Latitude:        l.Lat,
-			Longitude:       l.Lng,
-			VectorDistance:  l.VectorDistance,
-			TextMatchScore:  l.TextMatchScore,
-			RankFusionScore: l.RankFusionScore,
+		result = append(result, LocationEntityToLocationDTO(l))
+	}
+	return result
+}
+
+// LocationFilterDTOToEntity parses the textual bbox/near parameters into location filter options.
+// bbox is "min_lng,min_lat,max_lng,max_lat", a min_lng greater than max_lng crosses the antimeridian.
+// near is "lat,lng".
+func LocationFilterDTOToEntity(filter models.LocationFilter) (entity.LocationFilterOptions, error) {
+	options := entity.LocationFilterOptions{
+		Country:  filter.Country,
+		State:    filter.State,
+		Code:     filter.Code,
+		RadiusKm: filter.RadiusKm,
+	}
+
+	if filter.BBox != nil {
+		values, err := parseFloats(*filter.BBox, 4)
+		if err != nil {
+			return options, fmt.Errorf("invalid bbox: %w", err)
+		}
+
+		bbox := &entity.BoundingBox{
+			MinLng: values[0],
+			MinLat: values[1],
+			MaxLng: values[2],
+			MaxLat: values[3],
+		}
+
+		if !validLatitude(bbox.MinLat) || !validLatitude(bbox.MaxLat) || !validLongitude(bbox.MinLng) || !validLongitude(bbox.MaxLng) {
+			return options, fmt.Errorf("invalid bbox: coordinates out of range")
+		}
+
+		if bbox.MinLat > bbox.MaxLat {
+			return options, fmt.Errorf("invalid bbox: min_lat must not exceed max_lat")
+		}
+
+		options.BBox = bbox
+	}
+
+	if filter.Near != nil {
+		values, err := parseFloats(*filter.Near, 2)
+		if err != nil {
+			return options, fmt.Errorf("invalid near: %w", err)
+		}
+
+		if !validLatitude(values[0]) || !validLongitude(values[1]) {
+			return options, fmt.Errorf("invalid near: coordinates out of range")
+		}
+
+		options.Near = &entity.GeoPoint{
+			Lat: values[0],
+			Lng: values[1],
+		}
+	}
+
+	return options, nil
+}
+
+func parseFloats(value string, count int) ([]float64, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != count {
+		return nil, fmt.Errorf("expected %d comma separated numbers", count)
+	}
+
+	result := make([]float64, 0, count)
+	for _, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", part)
+		}
+		result = append(result, f)
+	}
+
+	return result, nil
+}
+
+func validLatitude(lat float64) bool {
+	return lat >= -90 && lat <= 90
+}
+
+func validLongitude(lng float64) bool {
+	return lng >= -180 && lng <= 180
+}
+
+func LocationsEntityToSuggestionsDTO(locations []*entity.Locations) []*models.AutocompleteSuggestion {
+	result := make([]*models.AutocompleteSuggestion, 0, len(locations))
+	for _, l := range locations {
+		result = append(result, &models.AutocompleteSuggestion{
+			ID: l.ID,
+			DisplayName: displayName(
+				localized(l.LocalizedCity, l.City),
+				localized(l.LocalizedState, l.State),
+				localized(l.LocalizedCountry, l.Country),
+			),
+			Country:          l.Country,
+			LocalizedCountry: l.LocalizedCountry,
+		})
+	}
+	return result
+}
+
+// localized returns the localized name when one is known and the canonical name otherwise
+func localized(name *string, fallback string) string {
+	if name != nil && *name != "" {
+		return *name
+	}
+	return fallback
+}
+
+// displayName joins non-empty parts with a comma, skipping parts equal to the previous one
+// (e.g. city-states where city and state have the same name)
+func displayName(parts ...string) string {
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part == "" || (len(result) > 0 && strings.EqualFold(result[len(result)-1], part)) {
+			continue
+		}
+		result = append(result, part)
+	}
+	return strings.Join(result, ", ")
+}
+
+func LocationEntityToLocationDetailsDTO(l *entity.Locations) *models.LocationDetails {
+	details := &models.LocationDetails{
+		ID:               l.ID,
+		City:             l.City,
+		State:            l.State,
+		Country:          l.Country,
+		Code:             l.Code,
+		Latitude:         l.Lat,
+		Longitude:        l.Lng,
+		LocalizedCity:    l.LocalizedCity,
+		Language:         l.LocalizedLanguage,
+		LocalizedState:   l.LocalizedState,
+		LocalizedCountry: l.LocalizedCountry,
+		GeonameIDs:       make([]int64, 0, len(l.GeonameIDs)),
+		AlternateNames:   make([]*models.AlternateName, 0, len(l.AlternateNames)),
+	}
+
+	for _, g := range l.GeonameIDs {
+		details.GeonameIDs = append(details.GeonameIDs, g.GeoNameID)
+	}
+
+	for _, a := range l.AlternateNames {
+		details.AlternateNames = append(details.AlternateNames, &models.AlternateName{
+			ID:           a.AlternateNameID,
+			Name:         a.AlternateName,
+			Language:     a.ISOLanguageCode,
+			Type:         a.Type,
+			GeonameID:    a.GeoNameID,
+			IsPreferred:  a.IsPreferred,
+			IsShort:      a.IsShort,
+			IsColloquial: a.IsColloquial,
+			IsHistoric:   a.IsHistoric,
 		})
 	}
-	return response
+
+	return details
 }
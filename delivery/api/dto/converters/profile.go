@@ -7,12 +7,13 @@ import (
 
 func ProfileEntityToProfileDTO(profile *entity.Users) models.Profile {
 	return models.Profile{
-		ID:        profile.ID,
-		Name:      profile.Name,
-		Email:     profile.Email,
-		Role:      string(profile.Role),
-		Status:    string(profile.Status),
-		CreatedAt: profile.CreatedAt,
-		UpdatedAt: profile.UpdatedAt,
+		ID:            profile.ID,
+		Name:          profile.Name,
+		Email:         profile.Email,
+		Role:          string(profile.Role),
+		Status:        string(profile.Status),
+		EmailVerified: profile.IsEmailVerified(),
+		CreatedAt:     profile.CreatedAt,
+		UpdatedAt:     profile.UpdatedAt,
 	}
 }
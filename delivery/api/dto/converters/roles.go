@@ -0,0 +1,34 @@
+package converters
+
+import (
+	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+func PermissionsEntityToDTO(permissions []entity.Permission) []string {
+	result := make([]string, len(permissions))
+	for i, permission := range permissions {
+		result[i] = string(permission)
+	}
+	return result
+}
+
+func PermissionsDTOToEntity(permissions []string) []entity.Permission {
+	result := make([]entity.Permission, len(permissions))
+	for i, permission := range permissions {
+		result[i] = entity.Permission(permission)
+	}
+	return result
+}
+
+// RolesEntityToDTO lists the roles in declaration order
+func RolesEntityToDTO(roles map[entity.UserRole][]entity.Permission) []models.Role {
+	result := make([]models.Role, 0, len(roles))
+	for _, role := range entity.UserRolesAll {
+		result = append(result, models.Role{
+			Role:        string(role),
+			Permissions: PermissionsEntityToDTO(roles[role]),
+		})
+	}
+	return result
+}
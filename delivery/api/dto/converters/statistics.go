@@ -0,0 +1,55 @@
+package converters
+
+import (
+	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+func StatisticsEntityToDTO(s *entity.SearchStatistics) *models.StatisticsResponse {
+	response := &models.StatisticsResponse{
+		From:              s.From,
+		To:                s.To,
+		TotalSearches:     s.TotalSearches,
+		PerDay:            make([]models.DailySearches, 0, len(s.PerDay)),
+		TopQueries:        QuerySearchesEntityToDTO(s.TopQueries),
+		ZeroResultQueries: QuerySearchesEntityToDTO(s.ZeroResultQueries),
+		PerUser:           make([]models.UserSearches, 0, len(s.PerUser)),
+	}
+
+	for _, d := range s.PerDay {
+		response.PerDay = append(response.PerDay, models.DailySearches{
+			Day:         d.Day.Format("2006-01-02"),
+			Searches:    d.Searches,
+			ZeroResults: d.ZeroResults,
+			Degraded:    d.Degraded,
+		})
+	}
+
+	if s.Latency != nil {
+		response.Latency = models.LatencyPercentiles{
+			P50Ms: s.Latency.P50Ms,
+			P95Ms: s.Latency.P95Ms,
+		}
+	}
+
+	for _, u := range s.PerUser {
+		response.PerUser = append(response.PerUser, models.UserSearches{
+			UserID:   u.UserID,
+			Username: u.Username,
+			Searches: u.Searches,
+		})
+	}
+
+	return response
+}
+
+func QuerySearchesEntityToDTO(queries []*entity.QuerySearches) []models.QuerySearches {
+	result := make([]models.QuerySearches, 0, len(queries))
+	for _, q := range queries {
+		result = append(result, models.QuerySearches{
+			Query:    q.Query,
+			Searches: q.Searches,
+		})
+	}
+	return result
+}
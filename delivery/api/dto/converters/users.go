@@ -1,20 +1,31 @@
 package converters
 
 import (
+	"time"
+
 	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
 	"github.com/AsaHero/whereismycity/internal/entity"
 )
 
 func UserEntityToUserDTO(user *entity.Users) *models.User {
+	var deletedAt *time.Time
+	if user.IsDeleted() {
+		deletedAt = &user.DeletedAt.Time
+	}
+
 	return &models.User{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Username:  user.Username,
-		Role:      string(user.Role),
-		Status:    string(user.Status),
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:             user.ID,
+		Name:           user.Name,
+		Email:          user.Email,
+		Username:       user.Username,
+		Role:           string(user.Role),
+		Status:         string(user.Status),
+		StatusReason:   user.StatusReason,
+		SuspendedUntil: user.SuspendedUntil,
+		EmailVerified:  user.IsEmailVerified(),
+		CreatedAt:      user.CreatedAt,
+		UpdatedAt:      user.UpdatedAt,
+		DeletedAt:      deletedAt,
 	}
 }
 
@@ -38,4 +49,3 @@ func UsersEntityToUsersDTO(users []*entity.Users) []*models.User {
 	}
 	return result
 }
-
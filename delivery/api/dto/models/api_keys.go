@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix" example:"wimc_1a2b3c4"`
+	Scopes     []string   `json:"scopes" example:"search,locations"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=255"`
+	// Scopes defaults to every scope when empty
+	Scopes        []string `json:"scopes" validate:"omitempty,dive,oneof=search locations"`
+	ExpiresInDays *int     `json:"expires_in_days" validate:"omitempty,min=1,max=3650"`
+}
+
+type CreateAPIKeyResponse struct {
+	APIKey
+	// Key is the plaintext key, it is shown only once
+	Key string `json:"key"`
+}
+
+type ListAPIKeysResponse struct {
+	APIKeys []APIKey `json:"api_keys"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogsRequest struct {
+	ActorID    *string `form:"actor_id" validate:"omitempty,uuid"`
+	Action     *string `form:"action" validate:"omitempty,max=100"`
+	TargetType *string `form:"target_type" validate:"omitempty,oneof=user api_key role"`
+	TargetID   *string `form:"target_id" validate:"omitempty,max=255"`
+	From       string  `form:"from" validate:"omitempty,datetime=2006-01-02"`
+	To         string  `form:"to" validate:"omitempty,datetime=2006-01-02"`
+	Limit      uint64  `form:"limit" validate:"omitempty,min=1,max=100"`
+	Page       uint64  `form:"page" validate:"omitempty,min=1"`
+}
+
+type AuditLog struct {
+	ID         int64   `json:"id"`
+	ActorID    *string `json:"actor_id"`
+	ActorRole  *string `json:"actor_role"`
+	Action     string  `json:"action" example:"user.updated"`
+	TargetType string  `json:"target_type" example:"user"`
+	TargetID   *string `json:"target_id"`
+	// Changes maps every changed field to its before and after values
+	Changes   json.RawMessage `json:"changes,omitempty" swaggertype:"object"`
+	Metadata  json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
+	IP        string          `json:"ip"`
+	UserAgent string          `json:"user_agent"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type AuditLogsResponse struct {
+	Total     uint64     `json:"total"`
+	AuditLogs []AuditLog `json:"audit_logs"`
+}
@@ -8,18 +8,179 @@ type Location struct {
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 
+	// LocalizedCity is the preferred city name in Language, present when a name in a requested language is known
+	LocalizedCity *string `json:"localized_city,omitempty" example:"Ташкент"`
+	Language      *string `json:"language,omitempty" example:"ru"`
+	// LocalizedState and LocalizedCountry are the preferred region names in the first requested language they are known in
+	LocalizedState   *string `json:"localized_state,omitempty" example:"Ташкент"`
+	LocalizedCountry *string `json:"localized_country,omitempty" example:"Узбекистан"`
+
 	VectorDistance  *float32 `json:"vector_distance"`
 	TextMatchScore  *int64   `json:"text_match_score"`
 	RankFusionScore *float64 `json:"rank_fusion_score"`
+	Score           *float64 `json:"score"`
+	DistanceMeters  *float64 `json:"distance_meters,omitempty"`
+}
+
+type LocationFilter struct {
+	Country  *string  `form:"country" json:"country" validate:"omitempty,min=2,max=255"`
+	State    *string  `form:"state" json:"state" validate:"omitempty,min=1,max=255"`
+	Code     *string  `form:"code" json:"code" validate:"omitempty,min=2,max=3"`
+	BBox     *string  `form:"bbox" json:"bbox"`
+	Near     *string  `form:"near" json:"near" validate:"required_with=RadiusKm"`
+	RadiusKm *float64 `form:"radius_km" json:"radius_km" validate:"required_with=Near,omitempty,gt=0,max=20000"`
 }
 
 type SearchRequest struct {
-	Query string `form:"q" validate:"required,min=2,max=100"`
-	Limit uint   `form:"limit" validate:"required,min=1,max=100"`
+	Query   string `form:"q" validate:"required,min=2,max=100"`
+	Limit   uint   `form:"limit" validate:"required,min=1,max=100"`
+	Ranking string `form:"ranking" validate:"omitempty,oneof=weighted typesense rrf minmax"`
+	Lang    string `form:"lang" validate:"omitempty,min=2,max=20"`
+	Explain bool   `form:"explain"`
+	LocationFilter
 }
 
 type SearchResponse struct {
 	Query     string      `json:"query"`
 	Limit     uint        `json:"limit"`
 	Locations []*Location `json:"locations"`
+	// Degraded is set when part of the search pipeline was unavailable and results may be less accurate
+	Degraded        bool     `json:"degraded"`
+	DegradedReasons []string `json:"degraded_reasons,omitempty" example:"embeddings_unavailable"`
+	// Explain is returned to callers with the search:explain permission who asked for explain=true
+	Explain *SearchExplanation `json:"explain,omitempty"`
+}
+
+type SearchExplanation struct {
+	NormalizedQuery string                `json:"normalized_query"`
+	Transliteration string                `json:"transliteration"`
+	Strategy        string                `json:"strategy"`
+	Subqueries      []SubqueryExplanation `json:"subqueries"`
+	Merges          []MergeExplanation    `json:"merges"`
+	Scores          []ScoreExplanation    `json:"scores"`
+}
+
+type SubqueryExplanation struct {
+	Query string           `json:"query"`
+	Error string           `json:"error,omitempty"`
+	Hits  []HitExplanation `json:"hits"`
+}
+
+type HitExplanation struct {
+	LocationID      int64    `json:"location_id"`
+	City            string   `json:"city"`
+	Position        int      `json:"position"`
+	VectorDistance  *float32 `json:"vector_distance"`
+	TextMatchScore  *int64   `json:"text_match_score"`
+	RankFusionScore *float64 `json:"rank_fusion_score"`
+}
+
+type MergeExplanation struct {
+	LocationID int64    `json:"location_id"`
+	Subquery   int      `json:"subquery"`
+	Position   int      `json:"position"`
+	Action     string   `json:"action" example:"merged"`
+	Updated    []string `json:"updated,omitempty" example:"vector_distance"`
+}
+
+type ScoreExplanation struct {
+	LocationID int64    `json:"location_id"`
+	City       string   `json:"city"`
+	Position   int      `json:"position"`
+	Score      *float64 `json:"score"`
+	Returned   bool     `json:"returned"`
+}
+
+type ReverseGeocodeRequest struct {
+	Lat   *float64 `form:"lat" validate:"required,latitude"`
+	Lng   *float64 `form:"lng" validate:"required,longitude"`
+	Limit uint     `form:"limit" validate:"omitempty,min=1,max=50"`
+	Lang  string   `form:"lang" validate:"omitempty,min=2,max=20"`
+}
+
+type ReverseGeocodeResponse struct {
+	Latitude  float64     `json:"latitude"`
+	Longitude float64     `json:"longitude"`
+	Limit     uint        `json:"limit"`
+	Locations []*Location `json:"locations"`
+}
+
+type BatchSearchQuery struct {
+	ID      string `json:"id" validate:"required,max=100"`
+	Query   string `json:"q" validate:"required,min=2,max=100"`
+	Limit   uint   `json:"limit" validate:"omitempty,min=1,max=100"`
+	Ranking string `json:"ranking" validate:"omitempty,oneof=weighted typesense rrf minmax"`
+	LocationFilter
+}
+
+type BatchSearchRequest struct {
+	Queries []BatchSearchQuery `json:"queries" validate:"required,min=1,max=100,unique=ID,dive"`
+	Lang    string             `json:"lang" validate:"omitempty,min=2,max=20"`
+}
+
+type BatchSearchError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type BatchSearchResult struct {
+	Query           string            `json:"query"`
+	Limit           uint              `json:"limit"`
+	Locations       []*Location       `json:"locations"`
+	Degraded        bool              `json:"degraded"`
+	DegradedReasons []string          `json:"degraded_reasons,omitempty" example:"embeddings_unavailable"`
+	Error           *BatchSearchError `json:"error,omitempty"`
+}
+
+type BatchSearchResponse struct {
+	Results map[string]*BatchSearchResult `json:"results"`
+}
+
+type AutocompleteRequest struct {
+	Query string `form:"q" validate:"required,min=1,max=100"`
+	Limit uint   `form:"limit" validate:"omitempty,min=1,max=20"`
+	Lang  string `form:"lang" validate:"omitempty,min=2,max=20"`
+	LocationFilter
+}
+
+type AutocompleteSuggestion struct {
+	ID int64 `json:"id"`
+	// DisplayName uses the localized names when a name in a requested language is known
+	DisplayName string `json:"display_name"`
+	Country     string `json:"country"`
+	// LocalizedCountry is the preferred country name in the first requested language it is known in
+	LocalizedCountry *string `json:"localized_country,omitempty" example:"Узбекистан"`
+}
+
+type AutocompleteResponse struct {
+	Query       string                    `json:"query"`
+	Suggestions []*AutocompleteSuggestion `json:"suggestions"`
+}
+
+type AlternateName struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Language     *string `json:"language"`
+	Type         string  `json:"type"`
+	GeonameID    int64   `json:"geoname_id"`
+	IsPreferred  bool    `json:"is_preferred"`
+	IsShort      bool    `json:"is_short"`
+	IsColloquial bool    `json:"is_colloquial"`
+	IsHistoric   bool    `json:"is_historic"`
+}
+
+type LocationDetails struct {
+	ID               int64            `json:"id"`
+	City             string           `json:"city"`
+	State            string           `json:"state"`
+	Country          string           `json:"country"`
+	Code             string           `json:"code"`
+	Latitude         float64          `json:"latitude"`
+	Longitude        float64          `json:"longitude"`
+	LocalizedCity    *string          `json:"localized_city,omitempty" example:"Ташкент"`
+	Language         *string          `json:"language,omitempty" example:"ru"`
+	LocalizedState   *string          `json:"localized_state,omitempty" example:"Ташкент"`
+	LocalizedCountry *string          `json:"localized_country,omitempty" example:"Узбекистан"`
+	GeonameIDs       []int64          `json:"geoname_ids"`
+	AlternateNames   []*AlternateName `json:"alternate_names"`
 }
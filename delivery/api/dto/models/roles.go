@@ -0,0 +1,16 @@
+package models
+
+type Role struct {
+	Role        string   `json:"role" example:"user"`
+	Permissions []string `json:"permissions" example:"search:read,locations:read"`
+}
+
+type ListRolesResponse struct {
+	Roles []Role `json:"roles"`
+	// Permissions lists every permission a role can be granted
+	Permissions []string `json:"permissions"`
+}
+
+type SetRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" validate:"required,dive,required"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type StatisticsRequest struct {
+	From  string `form:"from" validate:"omitempty,datetime=2006-01-02"`
+	To    string `form:"to" validate:"omitempty,datetime=2006-01-02"`
+	Limit int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type DailySearches struct {
+	Day         string `json:"day" example:"2025-05-01"`
+	Searches    int64  `json:"searches"`
+	ZeroResults int64  `json:"zero_results"`
+	Degraded    int64  `json:"degraded"`
+}
+
+type QuerySearches struct {
+	Query    string `json:"query"`
+	Searches int64  `json:"searches"`
+}
+
+type UserSearches struct {
+	UserID   *string `json:"user_id"`
+	Username *string `json:"username"`
+	Searches int64   `json:"searches"`
+}
+
+type LatencyPercentiles struct {
+	P50Ms float64 `json:"p50_ms"`
+	P95Ms float64 `json:"p95_ms"`
+}
+
+type StatisticsResponse struct {
+	From              time.Time          `json:"from"`
+	To                time.Time          `json:"to"`
+	TotalSearches     int64              `json:"total_searches"`
+	PerDay            []DailySearches    `json:"per_day"`
+	TopQueries        []QuerySearches    `json:"top_queries"`
+	ZeroResultQueries []QuerySearches    `json:"zero_result_queries"`
+	Latency           LatencyPercentiles `json:"latency"`
+	PerUser           []UserSearches     `json:"per_user"`
+}
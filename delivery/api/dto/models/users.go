@@ -3,14 +3,18 @@ package models
 import "time"
 
 type User struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Name      string    `json:"name"`
-	Username  string    `json:"username"`
-	Role      string    `json:"role"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             string     `json:"id"`
+	Email          string     `json:"email"`
+	Name           string     `json:"name"`
+	Username       string     `json:"username"`
+	Role           string     `json:"role"`
+	Status         string     `json:"status"`
+	StatusReason   *string    `json:"status_reason"`
+	SuspendedUntil *time.Time `json:"suspended_until"`
+	EmailVerified  bool       `json:"email_verified"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
 }
 
 type CreateUserRequest struct {
@@ -35,6 +39,7 @@ type SearchUsersRequest struct {
 	Name    *string `form:"name"`
 	Role    *string `form:"role"`
 	Status  *string `form:"status"`
+	Deleted *bool   `form:"deleted"`
 	Limit   uint64  `form:"limit" validate:"min=1,max=100"`
 	Page    uint64  `form:"page" validate:"min=1"`
 	SortBy  *string `form:"sort_by"`
@@ -45,3 +50,13 @@ type SearchUsersResponse struct {
 	Total int64   `json:"total"`
 	Users []*User `json:"users"`
 }
+
+type DeactivateUserRequest struct {
+	Reason *string `json:"reason" validate:"omitempty,max=500"`
+}
+
+type SuspendUserRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+	// Until ends the suspension automatically, omit to suspend until an admin activates the user
+	Until *time.Time `json:"until"`
+}
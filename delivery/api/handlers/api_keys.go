@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AsaHero/whereismycity/delivery/api/dto/converters"
+	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
+	"github.com/AsaHero/whereismycity/delivery/api/outerr"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/service/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAPIKey godoc
+// @Security 	 ApiKeyAuth
+// @Summary      Create API key
+// @Description  Create an API key for the search endpoints. The key is returned only once, store it safely
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Param request body models.CreateAPIKeyRequest true "Create API key request"
+// @Success 201 {object} models.CreateAPIKeyResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profile/api-keys [post]
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		outerr.Unauthorized(c, "user_id is required")
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	scopes := make(entity.APIKeyScopes, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scopes = append(scopes, entity.APIKeyScope(scope))
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays != nil {
+		t := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		expiresAt = &t
+	}
+
+	apiKey, key, err := h.apiKeyService.Create(ctx, userID, req.Name, scopes, expiresAt)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionAPIKeyCreated,
+		targetType: entity.AuditTargetAPIKey,
+		targetID:   apiKey.ID,
+		changes:    audit.Diff(nil, apiKey),
+	})
+
+	c.JSON(http.StatusCreated, &models.CreateAPIKeyResponse{
+		APIKey: converters.APIKeyEntityToDTO(apiKey),
+		Key:    key,
+	})
+}
+
+// ListAPIKeys godoc
+// @Security 	 ApiKeyAuth
+// @Summary      List API keys
+// @Description  List API keys of the current user, including revoked and expired ones
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} models.ListAPIKeysResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profile/api-keys [get]
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		outerr.Unauthorized(c, "user_id is required")
+		return
+	}
+
+	keys, err := h.apiKeyService.List(ctx, userID)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.ListAPIKeysResponse{
+		APIKeys: converters.APIKeysEntityToDTO(keys),
+	})
+}
+
+// RevokeAPIKey godoc
+// @Security 	 ApiKeyAuth
+// @Summary      Revoke API key
+// @Description  Revoke an API key of the current user, requests with it are rejected immediately
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Param id path string true "API key ID"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profile/api-keys/{id} [delete]
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		outerr.Unauthorized(c, "user_id is required")
+		return
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		outerr.BadRequest(c, "id is required")
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(ctx, userID, id); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionAPIKeyRevoked,
+		targetType: entity.AuditTargetAPIKey,
+		targetID:   id,
+	})
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AsaHero/whereismycity/delivery/api/dto/converters"
+	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
+	"github.com/AsaHero/whereismycity/delivery/api/outerr"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/gin-gonic/gin"
+)
+
+// auditEntry describes an audited action, the actor defaults to the authenticated caller
+type auditEntry struct {
+	action     entity.AuditAction
+	actor      *entity.Users
+	targetType string
+	targetID   string
+	changes    map[string]entity.AuditChange
+	metadata   map[string]any
+}
+
+// audit records an action together with the caller's IP and user agent
+func (h *Handler) audit(c *gin.Context, entry auditEntry) {
+	log := &entity.AuditLogs{
+		Action:     entry.action,
+		TargetType: entry.targetType,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+
+	if entry.actor != nil {
+		log.ActorID = &entry.actor.ID
+		role := string(entry.actor.Role)
+		log.ActorRole = &role
+	} else {
+		log.ActorID = userID(c)
+		if role := c.GetString("role"); role != "" {
+			log.ActorRole = &role
+		}
+	}
+
+	if entry.targetID != "" {
+		log.TargetID = &entry.targetID
+	}
+
+	if len(entry.changes) > 0 {
+		log.Changes = marshalAudit(entry.changes)
+	}
+
+	if len(entry.metadata) > 0 {
+		log.Metadata = marshalAudit(entry.metadata)
+	}
+
+	h.auditService.Record(c.Request.Context(), log)
+}
+
+func marshalAudit(v any) *string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		inerr.Err(err)
+		return nil
+	}
+
+	s := string(b)
+	return &s
+}
+
+// GetAuditLogs godoc
+// @Security 	 BasicAuth
+// @Summary      Audit logs
+// @Description  Admin operations, logins, password changes and API key events, newest first
+// @Tags         audit
+// @Accept       json
+// @Produce      json
+// @Param actor_id query string false "Actor user ID"
+// @Param action query string false "Action" example("user.updated")
+// @Param target_type query string false "Target type" Enums(user, api_key, role)
+// @Param target_id query string false "Target ID"
+// @Param from query string false "First day, inclusive. 30 days before to by default" example("2025-05-01")
+// @Param to query string false "Last day, inclusive. Today by default" example("2025-05-31")
+// @Param limit query integer false "Page size" minimum(1) maximum(100) default(50)
+// @Param page query integer false "Page" minimum(1) default(1)
+// @Success 200 {object} models.AuditLogsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /admin/audit-logs [get]
+func (h *Handler) GetAuditLogs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.AuditLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	from, to, err := dateRange(req.From, req.To)
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	filter := &entity.AuditLogFilter{
+		ActorID:    req.ActorID,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		From:       from,
+		To:         to,
+	}
+	if req.Action != nil {
+		action := entity.AuditAction(*req.Action)
+		filter.Action = &action
+	}
+
+	total, logs, err := h.auditService.List(ctx, filter, req.Limit, req.Page)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &models.AuditLogsResponse{
+		Total:     total,
+		AuditLogs: converters.AuditLogsEntityToDTO(logs),
+	})
+}
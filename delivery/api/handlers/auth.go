@@ -5,7 +5,8 @@ import (
 
 	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
 	"github.com/AsaHero/whereismycity/delivery/api/outerr"
-	"github.com/AsaHero/whereismycity/pkg/security"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
 	"github.com/gin-gonic/gin"
 )
 
@@ -37,32 +38,45 @@ func (h *Handler) Login(c *gin.Context) {
 
 	user, err := h.authService.Login(ctx, req.Email, req.Password)
 	if err != nil {
+		h.audit(c, auditEntry{
+			action:   entity.AuditActionLoginFailed,
+			metadata: map[string]any{"login": req.Email, "reason": err.Error()},
+		})
 		outerr.HandleError(c, err)
 		return
 	}
 
-	accessToken, refreshToken, err := security.GenerateTokenPair(user.ID, h.config.Token.Secret)
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionLogin,
+		actor:      user,
+		targetType: entity.AuditTargetUser,
+		targetID:   user.ID,
+	})
+
+	tokens, err := h.authService.IssueTokens(ctx, user.ID)
 	if err != nil {
 		outerr.HandleError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, models.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	})
 }
 
 // RefreshToken godoc
 
 // @Summary      Refresh token
-// @Description  Refresh token
+// @Description  Exchange a refresh token for a new pair. Every refresh token can be used once, replaying one revokes all tokens of its session
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param request body models.RefreshTokenRequest true "Refresh token request"
 // @Success 200 {object} models.LoginResponse
 // @Failure 400 {object} outerr.ErrorResponse
+// @Failure 401 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
 // @Failure 500 {object} outerr.ErrorResponse
 // @Router /auth/refresh [post]
 func (h *Handler) RefreshToken(c *gin.Context) {
@@ -79,40 +93,28 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	tokenClaims, err := security.ParseRefreshToken(req.RefreshToken, h.config.Token.Secret)
-	if err != nil {
-		outerr.HandleError(c, err)
-		return
-	}
-
-	// get the user from the token claims
-	user, err := h.userService.GetByID(ctx, tokenClaims.UserID)
-	if err != nil {
-		outerr.HandleError(c, err)
-		return
-	}
-
-	accessToken, refreshToken, err := security.GenerateTokenPair(user.ID, h.config.Token.Secret)
+	tokens, err := h.authService.RefreshTokens(ctx, req.RefreshToken)
 	if err != nil {
 		outerr.HandleError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, models.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	})
 }
 
 // Register godoc
 
 // @Summary      Register
-// @Description  Register
+// @Description  Register a user and mail a link to verify the email address
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param request body models.RegisterRequest true "Register request"
 // @Success 201 {object} models.LoginResponse
+// @Success 202 {object} models.Empty "Registered, pending admin approval"
 // @Failure 400 {object} outerr.ErrorResponse
 // @Failure 500 {object} outerr.ErrorResponse
 // @Router /auth/register [post]
@@ -136,14 +138,212 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := security.GenerateTokenPair(user.ID, h.config.Token.Secret)
+	// The account exists either way, the user can ask for the link again
+	if err := h.verificationService.Send(ctx, user); err != nil {
+		inerr.Err(err)
+	}
+
+	// Accounts waiting for approval get their tokens by logging in once activated
+	if !user.IsActive() {
+		c.JSON(http.StatusAccepted, models.Empty{})
+		return
+	}
+
+	tokens, err := h.authService.IssueTokens(ctx, user.ID)
 	if err != nil {
 		outerr.HandleError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, models.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
+
+// Logout godoc
+// @Summary      Logout
+// @Description  Revoke the session of the refresh token, or every session of its user when everywhere is set. Used or revoked refresh tokens are rejected
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param request body models.LogoutRequest true "Logout request"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 401 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	userID, err := h.authService.Logout(ctx, req.RefreshToken, req.Everywhere)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	action := entity.AuditActionLogout
+	if req.Everywhere {
+		action = entity.AuditActionLogoutEverywhere
+	}
+	h.audit(c, auditEntry{
+		action:     action,
+		targetType: entity.AuditTargetUser,
+		targetID:   userID,
+	})
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// VerifyEmail godoc
+
+// @Summary      Verify email
+// @Description  Confirm the email address with the token from the verification mail
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param request body models.VerifyEmailRequest true "Verify email request"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /auth/verify-email [post]
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if err := h.verificationService.Verify(ctx, req.Token); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// ResendVerificationEmail godoc
+// @Security 	 ApiKeyAuth
+// @Summary      Resend verification email
+// @Description  Mail a new email verification link to the current user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /auth/verify-email/resend [post]
+func (h *Handler) ResendVerificationEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		outerr.Unauthorized(c, "user_id is required")
+		return
+	}
+
+	if err := h.verificationService.Resend(ctx, userID); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// ForgotPassword godoc
+
+// @Summary      Forgot password
+// @Description  Mail a password reset link. The response is the same whether or not the email belongs to an account
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param request body models.ForgotPasswordRequest true "Forgot password request"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /auth/password/forgot [post]
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if err := h.passwordResetService.Forgot(ctx, req.Email); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.audit(c, auditEntry{
+		action:   entity.AuditActionPasswordResetRequested,
+		metadata: map[string]any{"email": req.Email},
+	})
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Set a new password with the token from the reset mail, log out every session and revoke every API key
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param request body models.ResetPasswordRequest true "Reset password request"
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /auth/password/reset [post]
+func (h *Handler) ResetPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	userID, err := h.passwordResetService.Reset(ctx, req.Token, req.Password)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionPasswordReset,
+		targetType: entity.AuditTargetUser,
+		targetID:   userID,
 	})
+
+	c.JSON(http.StatusOK, models.Empty{})
 }
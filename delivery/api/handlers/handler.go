@@ -1,37 +1,132 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/AsaHero/whereismycity/delivery/api/validation"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/ratelimit"
+	"github.com/AsaHero/whereismycity/internal/service/apikeys"
+	"github.com/AsaHero/whereismycity/internal/service/audit"
 	"github.com/AsaHero/whereismycity/internal/service/auth"
+	"github.com/AsaHero/whereismycity/internal/service/locations"
+	"github.com/AsaHero/whereismycity/internal/service/passwordreset"
+	"github.com/AsaHero/whereismycity/internal/service/rbac"
 	"github.com/AsaHero/whereismycity/internal/service/search"
+	"github.com/AsaHero/whereismycity/internal/service/statistics"
 	"github.com/AsaHero/whereismycity/internal/service/users"
+	"github.com/AsaHero/whereismycity/internal/service/verification"
 	"github.com/AsaHero/whereismycity/pkg/bot"
 	"github.com/AsaHero/whereismycity/pkg/config"
+	"github.com/gin-gonic/gin"
 )
 
 type HandlerOptions struct {
-	Bot           *bot.Bot
-	AuthService   auth.AuthService
-	UserService   users.Service
-	SearchService search.Service
+	Bot                  *bot.Bot
+	AuthService          auth.AuthService
+	UserService          users.Service
+	SearchService        search.Service
+	LocationService      locations.Service
+	StatisticsService    statistics.Service
+	APIKeyService        apikeys.Service
+	RateLimitStore       ratelimit.Store
+	RateLimitPolicy      ratelimit.Policy
+	VerificationService  verification.Service
+	PasswordResetService passwordreset.Service
+	AuditService         audit.Service
+	RBACService          rbac.Service
 }
 
 type Handler struct {
-	bot           *bot.Bot
-	config        *config.Config
-	validator     *validation.Validator
-	searchService search.Service
-	userService   users.Service
-	authService   auth.AuthService
+	bot                  *bot.Bot
+	config               *config.Config
+	validator            *validation.Validator
+	searchService        search.Service
+	userService          users.Service
+	authService          auth.AuthService
+	locationService      locations.Service
+	statisticsService    statistics.Service
+	apiKeyService        apikeys.Service
+	verificationService  verification.Service
+	passwordResetService passwordreset.Service
+	auditService         audit.Service
+	rbacService          rbac.Service
 }
 
 func New(cfg *config.Config, validator *validation.Validator, opt *HandlerOptions) *Handler {
 	return &Handler{
-		bot:           opt.Bot,
-		config:        cfg,
-		validator:     validator,
-		searchService: opt.SearchService,
-		userService:   opt.UserService,
-		authService:   opt.AuthService,
+		bot:                  opt.Bot,
+		config:               cfg,
+		validator:            validator,
+		searchService:        opt.SearchService,
+		userService:          opt.UserService,
+		authService:          opt.AuthService,
+		locationService:      opt.LocationService,
+		statisticsService:    opt.StatisticsService,
+		apiKeyService:        opt.APIKeyService,
+		verificationService:  opt.VerificationService,
+		passwordResetService: opt.PasswordResetService,
+		auditService:         opt.AuditService,
+		rbacService:          opt.RBACService,
+	}
+}
+
+// hasPermission reports whether the role of the authenticated caller grants the permission,
+// anonymous callers have none
+func (h *Handler) hasPermission(c *gin.Context, permission entity.Permission) (bool, error) {
+	value, _ := c.Get("role")
+	role, ok := value.(string)
+	if !ok || role == "" {
+		return false, nil
+	}
+
+	return h.rbacService.HasPermission(c.Request.Context(), entity.UserRole(role), permission)
+}
+
+// userID returns the ID of the authenticated caller, nil for anonymous requests
+func userID(c *gin.Context) *string {
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*entity.Users); ok && u != nil {
+			return &u.ID
+		}
+	}
+
+	if id, ok := c.Get("user_id"); ok {
+		if s, ok := id.(string); ok && s != "" {
+			return &s
+		}
 	}
+
+	return nil
+}
+
+// dateRange parses an inclusive range of "2006-01-02" days into the half-open range [from, to) that
+// ends at the start of the day after "to". Today and the 30 days before it are used by default
+func dateRange(fromDay, toDay string) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if toDay != "" {
+		day, err := time.Parse("2006-01-02", toDay)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %q is not a 2006-01-02 date", toDay)
+		}
+		to = day
+	}
+	to = to.AddDate(0, 0, 1)
+
+	from := to.AddDate(0, 0, -30)
+	if fromDay != "" {
+		day, err := time.Parse("2006-01-02", fromDay)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %q is not a 2006-01-02 date", fromDay)
+		}
+		from = day
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errors.New("from must not be after to")
+	}
+
+	return from, to, nil
 }
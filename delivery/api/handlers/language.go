@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxLanguages caps how many Accept-Language entries are looked up
+const maxLanguages = 5
+
+// requestLanguages returns the languages names should be localized to, most wanted first.
+// The lang parameter wins over the Accept-Language header; regional tags also yield their base language.
+func requestLanguages(c *gin.Context, lang string) []string {
+	if lang = strings.TrimSpace(lang); lang != "" {
+		return expandLanguage(nil, lang)
+	}
+
+	header := c.GetHeader("Accept-Language")
+	if header == "" {
+		return nil
+	}
+
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	var languages []string
+	for i, t := range tags {
+		if i == maxLanguages {
+			break
+		}
+		languages = expandLanguage(languages, t.tag)
+	}
+
+	return languages
+}
+
+// expandLanguage appends the lowercase tag and its base language, skipping duplicates
+func expandLanguage(languages []string, tag string) []string {
+	tag = strings.ToLower(tag)
+	candidates := []string{tag}
+	if base, _, found := strings.Cut(tag, "-"); found {
+		candidates = append(candidates, base)
+	}
+
+	for _, candidate := range candidates {
+		exists := false
+		for _, l := range languages {
+			if l == candidate {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			languages = append(languages, candidate)
+		}
+	}
+
+	return languages
+}
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/AsaHero/whereismycity/delivery/api/dto/converters"
 	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
@@ -12,6 +14,8 @@ import (
 
 // Search godoc
 // @Security 	 BasicAuth
+// @Security 	 ApiKeyAuth
+// @Security 	 XApiKeyAuth
 // @Summary Search for locations
 // @Description Search for locations
 // @Tags locations
@@ -19,8 +23,19 @@ import (
 // @Produce json
 // @Param q query string true "Searching query" example("New York")
 // @Param limit query integer false "Locations in response" minimum(1) maximum(100) default(20) example(20)
+// @Param ranking query string false "Ranking strategy, the configured one by default" Enums(weighted, typesense, rrf, minmax)
+// @Param lang query string false "Language of localized names, Accept-Language is used when omitted" example("ru")
+// @Param explain query boolean false "Attach a trace of subqueries, merges and scores, requires the search:explain permission"
+// @Param country query string false "Only locations in this country" example("Uzbekistan")
+// @Param state query string false "Only locations in this state" example("Tashkent")
+// @Param code query string false "Only locations with this country code" example("UZ")
+// @Param bbox query string false "Bounding box as min_lng,min_lat,max_lng,max_lat, min_lng above max_lng crosses the antimeridian" example("55.9,37.1,73.2,45.6")
+// @Param near query string false "Center point as lat,lng, requires radius_km" example("41.2995,69.2401")
+// @Param radius_km query number false "Radius around near in kilometers, requires near" example(50)
 // @Success 200 {object} models.SearchResponse
 // @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
 // @Failure 500 {object} outerr.ErrorResponse
 // @Router /search [get]
 func (h *Handler) Search(c *gin.Context) {
@@ -37,15 +52,309 @@ func (h *Handler) Search(c *gin.Context) {
 		return
 	}
 
-	locations, err := h.searchService.Search(ctx, req.Query, req.Limit, entity.LocationFilterOptions{})
+	if req.Explain {
+		allowed, err := h.hasPermission(c, entity.PermissionSearchExplain)
+		if err != nil {
+			outerr.HandleError(c, err)
+			return
+		}
+
+		if !allowed {
+			outerr.Forbidden(c, "explain requires the "+string(entity.PermissionSearchExplain)+" permission")
+			return
+		}
+	}
+
+	filter, err := converters.LocationFilterDTOToEntity(req.LocationFilter)
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.searchService.Search(ctx, req.Query, req.Limit, entity.SearchOptions{
+		Filter:  filter,
+		Ranking: entity.RankingStrategy(req.Ranking),
+		Explain: req.Explain,
+		UserID:  userID(c),
+	})
 	if err != nil {
 		outerr.HandleError(c, err)
 		return
 	}
 
-	response := converters.LocationEntityToDTO(locations)
+	h.locationService.Localize(ctx, result.Locations, requestLanguages(c, req.Lang))
+
+	response := converters.LocationEntityToDTO(result)
 	response.Limit = req.Limit
 	response.Query = req.Query
 
 	c.JSON(http.StatusOK, response)
 }
+
+// ReverseGeocode godoc
+// @Security 	 BasicAuth
+// @Security 	 ApiKeyAuth
+// @Security 	 XApiKeyAuth
+// @Summary Find nearest locations for a coordinate
+// @Description Resolve a GPS coordinate to the nearest known locations, ordered by distance
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param lat query number true "Latitude" minimum(-90) maximum(90) example(41.2995)
+// @Param lng query number true "Longitude" minimum(-180) maximum(180) example(69.2401)
+// @Param limit query integer false "Locations in response" minimum(1) maximum(50) default(5) example(5)
+// @Param lang query string false "Language of localized names, Accept-Language is used when omitted" example("ru")
+// @Success 200 {object} models.ReverseGeocodeResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /reverse [get]
+func (h *Handler) ReverseGeocode(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.ReverseGeocodeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+
+	locations, err := h.searchService.ReverseGeocode(ctx, *req.Lat, *req.Lng, req.Limit)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.locationService.Localize(ctx, locations, requestLanguages(c, req.Lang))
+
+	c.JSON(http.StatusOK, models.ReverseGeocodeResponse{
+		Latitude:  *req.Lat,
+		Longitude: *req.Lng,
+		Limit:     req.Limit,
+		Locations: converters.LocationsEntityToLocationsDTO(locations),
+	})
+}
+
+// BatchSearch godoc
+// @Security 	 BasicAuth
+// @Security 	 ApiKeyAuth
+// @Security 	 XApiKeyAuth
+// @Summary Search for many locations at once
+// @Description Resolve up to 100 free-text queries in one call. Results are keyed by query ID and failures are reported per query.
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param request body models.BatchSearchRequest true "Batch search request"
+// @Param Accept-Language header string false "Languages of localized names when lang is omitted" example("ru-RU,ru;q=0.9")
+// @Success 200 {object} models.BatchSearchResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /search/batch [post]
+func (h *Handler) BatchSearch(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.BatchSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	queries := make([]entity.BatchSearchQuery, 0, len(req.Queries))
+	for _, q := range req.Queries {
+		filter, err := converters.LocationFilterDTOToEntity(q.LocationFilter)
+		if err != nil {
+			outerr.BadRequest(c, fmt.Sprintf("query %s: %s", q.ID, err.Error()))
+			return
+		}
+
+		queries = append(queries, entity.BatchSearchQuery{
+			SearchOptions: entity.SearchOptions{
+				Filter:  filter,
+				Ranking: entity.RankingStrategy(q.Ranking),
+				UserID:  userID(c),
+			},
+			ID:    q.ID,
+			Query: q.Query,
+			Limit: q.Limit,
+		})
+	}
+
+	results, err := h.searchService.BatchSearch(ctx, queries)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	var located []*entity.Locations
+	for _, r := range results {
+		located = append(located, r.Locations...)
+	}
+	h.locationService.Localize(ctx, located, requestLanguages(c, req.Lang))
+
+	response := models.BatchSearchResponse{
+		Results: make(map[string]*models.BatchSearchResult, len(results)),
+	}
+
+	for _, r := range results {
+		result := &models.BatchSearchResult{
+			Query:           r.Query,
+			Limit:           r.Limit,
+			Locations:       converters.LocationsEntityToLocationsDTO(r.Locations),
+			Degraded:        r.Degraded,
+			DegradedReasons: converters.DegradedReasonsEntityToDTO(r.DegradedReasons),
+		}
+
+		if r.Err != nil {
+			_, errResponse := outerr.Resolve(r.Err)
+			result.Error = &models.BatchSearchError{
+				Code:    errResponse.Code,
+				Message: errResponse.Message,
+			}
+		}
+
+		response.Results[r.ID] = result
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Autocomplete godoc
+// @Security 	 BasicAuth
+// @Security 	 ApiKeyAuth
+// @Security 	 XApiKeyAuth
+// @Summary Autocomplete location names
+// @Description Fast prefix search for city pickers. Skips transliteration and embeddings and returns a compact payload. Display names are localized when a name in a requested language is known.
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param q query string true "Typed prefix" example("Tash")
+// @Param limit query integer false "Suggestions in response" minimum(1) maximum(20) default(10) example(10)
+// @Param lang query string false "Language of localized names, Accept-Language is used when omitted" example("ru")
+// @Param country query string false "Only locations in this country" example("Uzbekistan")
+// @Param code query string false "Only locations with this country code" example("UZ")
+// @Success 200 {object} models.AutocompleteResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /autocomplete [get]
+func (h *Handler) Autocomplete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.AutocompleteRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	filter, err := converters.LocationFilterDTOToEntity(req.LocationFilter)
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	locations, err := h.searchService.Autocomplete(ctx, req.Query, req.Limit, filter)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.locationService.Localize(ctx, locations, requestLanguages(c, req.Lang))
+
+	c.JSON(http.StatusOK, models.AutocompleteResponse{
+		Query:       req.Query,
+		Suggestions: converters.LocationsEntityToSuggestionsDTO(locations),
+	})
+}
+
+// GetLocation godoc
+// @Security 	 BasicAuth
+// @Security 	 ApiKeyAuth
+// @Security 	 XApiKeyAuth
+// @Summary Get location
+// @Description Get location with its alternate names and linked GeoNames IDs
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param id path integer true "Location ID"
+// @Param lang query string false "Language of localized names, Accept-Language is used when omitted" example("ru")
+// @Success 200 {object} models.LocationDetails
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /locations/{id} [get]
+func (h *Handler) GetLocation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		outerr.BadRequest(c, "id must be a positive integer")
+		return
+	}
+
+	location, err := h.locationService.GetByID(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.locationService.Localize(ctx, []*entity.Locations{location}, requestLanguages(c, c.Query("lang")))
+
+	c.JSON(http.StatusOK, converters.LocationEntityToLocationDetailsDTO(location))
+}
+
+// GetLocationByGeonameID godoc
+// @Security 	 BasicAuth
+// @Security 	 ApiKeyAuth
+// @Security 	 XApiKeyAuth
+// @Summary Get location by GeoNames ID
+// @Description Get location linked to a GeoNames identifier, with its alternate names and linked GeoNames IDs
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param geoname_id path integer true "GeoNames ID"
+// @Param lang query string false "Language of localized names, Accept-Language is used when omitted" example("ru")
+// @Success 200 {object} models.LocationDetails
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 429 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /locations/by-geoname/{geoname_id} [get]
+func (h *Handler) GetLocationByGeonameID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	geonameID, err := strconv.ParseInt(c.Param("geoname_id"), 10, 64)
+	if err != nil || geonameID <= 0 {
+		outerr.BadRequest(c, "geoname_id must be a positive integer")
+		return
+	}
+
+	location, err := h.locationService.GetByGeonameID(ctx, geonameID)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.locationService.Localize(ctx, []*entity.Locations{location}, requestLanguages(c, c.Query("lang")))
+
+	c.JSON(http.StatusOK, converters.LocationEntityToLocationDetailsDTO(location))
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/AsaHero/whereismycity/delivery/api/dto/converters"
 	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
 	"github.com/AsaHero/whereismycity/delivery/api/outerr"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/service/audit"
 	"github.com/AsaHero/whereismycity/pkg/security"
 	"github.com/gin-gonic/gin"
 )
@@ -82,12 +85,17 @@ func (h *Handler) PatchProfile(c *gin.Context) {
 		return
 	}
 
+	before := *user
+
 	if req.Name != nil {
 		user.Name = *req.Name
 	}
 
-	if req.Email != nil {
+	emailChanged := false
+	if req.Email != nil && *req.Email != user.Email {
 		user.Email = *req.Email
+		user.EmailVerifiedAt = nil
+		emailChanged = true
 	}
 
 	if req.Username != nil {
@@ -114,5 +122,61 @@ func (h *Handler) PatchProfile(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionUserUpdated,
+		targetType: entity.AuditTargetUser,
+		targetID:   user.ID,
+		changes:    audit.Diff(&before, user),
+	})
+
+	if user.PasswordHash != before.PasswordHash {
+		h.audit(c, auditEntry{
+			action:     entity.AuditActionPasswordChanged,
+			targetType: entity.AuditTargetUser,
+			targetID:   user.ID,
+		})
+	}
+
+	// The new address has to be verified again
+	if emailChanged {
+		if err := h.verificationService.Send(ctx, user); err != nil {
+			inerr.Err(err)
+		}
+	}
+
+	c.JSON(http.StatusOK, models.Empty{})
+}
+
+// LogoutEverywhere godoc
+// @Security 	 ApiKeyAuth
+// @Summary      Logout everywhere
+// @Description  Revoke the refresh tokens of every session of the current user and every access token issued so far
+// @Tags         profile
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} models.Empty
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /profile/logout-everywhere [post]
+func (h *Handler) LogoutEverywhere(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		outerr.Unauthorized(c, "user_id is required")
+		return
+	}
+
+	if err := h.authService.LogoutEverywhere(ctx, userID); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionLogoutEverywhere,
+		targetType: entity.AuditTargetUser,
+		targetID:   userID,
+	})
+
 	c.JSON(http.StatusOK, models.Empty{})
 }
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AsaHero/whereismycity/delivery/api/dto/converters"
+	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
+	"github.com/AsaHero/whereismycity/delivery/api/outerr"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// ListRoles godoc
+// @Security 	 BasicAuth
+// @Summary      List roles
+// @Description  Every role with the permissions it grants
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Success 200 {object} models.ListRolesResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /admin/roles [get]
+func (h *Handler) ListRoles(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	roles, err := h.rbacService.List(ctx)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ListRolesResponse{
+		Roles:       converters.RolesEntityToDTO(roles),
+		Permissions: converters.PermissionsEntityToDTO(entity.PermissionsAll),
+	})
+}
+
+// SetRolePermissions godoc
+// @Security 	 BasicAuth
+// @Summary      Set role permissions
+// @Description  Replace the permissions of a role, the admin role must keep roles:write
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Param role path string true "Role" Enums(admin, user, guest)
+// @Param request body models.SetRolePermissionsRequest true "Permissions"
+// @Success 200 {object} models.Role
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 403 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /admin/roles/{role}/permissions [put]
+func (h *Handler) SetRolePermissions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	role := entity.UserRole(c.Param("role"))
+	if !role.IsValid() {
+		outerr.BadRequest(c, "unknown role")
+		return
+	}
+
+	var req models.SetRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	before, err := h.rbacService.List(ctx)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if err := h.rbacService.SetPermissions(ctx, role, converters.PermissionsDTOToEntity(req.Permissions)); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	after, err := h.rbacService.List(ctx)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionRolePermissionsChanged,
+		targetType: entity.AuditTargetRole,
+		targetID:   string(role),
+		changes: map[string]entity.AuditChange{
+			"permissions": {Before: before[role], After: after[role]},
+		},
+	})
+
+	c.JSON(http.StatusOK, models.Role{
+		Role:        string(role),
+		Permissions: converters.PermissionsEntityToDTO(after[role]),
+	})
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/AsaHero/whereismycity/delivery/api/dto/converters"
+	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
+	"github.com/AsaHero/whereismycity/delivery/api/outerr"
+	"github.com/gin-gonic/gin"
+)
+
+// GetStatistics godoc
+// @Security 	 BasicAuth
+// @Summary      Search statistics
+// @Description  Searches per day, top and zero-result queries, latency percentiles and per-user volumes over a date range
+// @Tags         statistics
+// @Accept       json
+// @Produce      json
+// @Param from query string false "First day, inclusive. 30 days before to by default" example("2025-05-01")
+// @Param to query string false "Last day, inclusive. Today by default" example("2025-05-31")
+// @Param limit query integer false "Entries in every top list" minimum(1) maximum(100) default(10)
+// @Success 200 {object} models.StatisticsResponse
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /admin/statistics [get]
+func (h *Handler) GetStatistics(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req models.StatisticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	from, to, err := dateRange(req.From, req.To)
+	if err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	statistics, err := h.statisticsService.GetStatistics(ctx, from, to, req.Limit)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, converters.StatisticsEntityToDTO(statistics))
+}
@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/AsaHero/whereismycity/delivery/api/dto/converters"
 	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
 	"github.com/AsaHero/whereismycity/delivery/api/outerr"
 	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/service/audit"
 	"github.com/AsaHero/whereismycity/pkg/security"
 	"github.com/gin-gonic/gin"
 )
@@ -43,18 +45,30 @@ func (h *Handler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	err = h.userService.Create(ctx, &entity.Users{
-		Name:         req.Name,
-		Email:        req.Email,
-		PasswordHash: passwordHash,
-		Username:     req.Username,
-		Role:         entity.UserRole(req.Role),
-	})
-	if err != nil {
+	// Admins vouch for the addresses of the users they create
+	now := time.Now()
+	user := &entity.Users{
+		Name:            req.Name,
+		Email:           req.Email,
+		PasswordHash:    passwordHash,
+		Username:        req.Username,
+		Role:            entity.UserRole(req.Role),
+		Status:          entity.UserStatusActive,
+		EmailVerifiedAt: &now,
+	}
+
+	if err := h.userService.Create(ctx, user); err != nil {
 		outerr.HandleError(c, err)
 		return
 	}
 
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionUserCreated,
+		targetType: entity.AuditTargetUser,
+		targetID:   user.ID,
+		changes:    audit.Diff(nil, user),
+	})
+
 	c.JSON(http.StatusCreated, models.Empty{})
 }
 
@@ -129,6 +143,8 @@ func (h *Handler) PatchUser(c *gin.Context) {
 		return
 	}
 
+	before := *user
+
 	if req.Name != nil {
 		user.Name = *req.Name
 	}
@@ -160,13 +176,20 @@ func (h *Handler) PatchUser(c *gin.Context) {
 		return
 	}
 
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionUserUpdated,
+		targetType: entity.AuditTargetUser,
+		targetID:   user.ID,
+		changes:    audit.Diff(&before, user),
+	})
+
 	c.JSON(http.StatusOK, models.Empty{})
 }
 
 // DeleteUser godoc
 // @Security 	 BasicAuth
 // @Summary      Delete user
-// @Description  Delete user
+// @Description  Soft delete a user, it can be restored until the purge job removes it
 // @Tags         users
 // @Accept       json
 // @Produce      json
@@ -184,14 +207,65 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 		return
 	}
 
+	user, err := h.userService.GetByID(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
 	if err := h.userService.Delete(ctx, id); err != nil {
 		outerr.HandleError(c, err)
 		return
 	}
 
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionUserDeleted,
+		targetType: entity.AuditTargetUser,
+		targetID:   id,
+		changes:    audit.Diff(user, nil),
+	})
+
 	c.JSON(http.StatusOK, models.Empty{})
 }
 
+// RestoreUser godoc
+// @Security 	 BasicAuth
+// @Summary      Restore user
+// @Description  Restore a soft deleted user that wasn't purged yet. Fails with a conflict when its username or email was registered again
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param id path string true "User ID"
+// @Success 200 {object} models.User
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 409 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /admin/users/{id}/restore [post]
+func (h *Handler) RestoreUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id := c.Param("id")
+	if id == "" {
+		outerr.BadRequest(c, "id is required")
+		return
+	}
+
+	user, err := h.userService.Restore(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionUserRestored,
+		targetType: entity.AuditTargetUser,
+		targetID:   id,
+	})
+
+	c.JSON(http.StatusOK, converters.UserEntityToUserDTO(user))
+}
+
 // SearchUsers godoc
 // @Security 	 BasicAuth
 // @Summary      Search users
@@ -204,6 +278,7 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 // @Param name query string false "Search by name" example("John")
 // @Param role query string false "Search by role" enum(admin, user, guest)
 // @Param status query string false "Search by status" enum(active, inactive)
+// @Param deleted query boolean false "List only deleted users"
 // @Param page query integer false "Page number" minimum(1) default(1)
 // @Param limit query integer false "Users in response" minimum(1) maximum(100) default(20) example(20)
 // @Param sort_by query string false "Sort by field" example("name")
@@ -227,11 +302,12 @@ func (h *Handler) SearchUsers(c *gin.Context) {
 	}
 
 	total, users, err := h.userService.List(ctx, req.Limit, req.Page, &entity.UserFilterOptions{
-		Search: req.Search,
-		Email:  req.Email,
-		Name:   req.Name,
-		Role:   req.Role,
-		Status: req.Status,
+		Search:  req.Search,
+		Email:   req.Email,
+		Name:    req.Name,
+		Role:    req.Role,
+		Status:  req.Status,
+		Deleted: req.Deleted,
 	}, &entity.SortOptions{
 		SortBy:    req.SortBy,
 		SortOrder: req.SortDir,
@@ -248,3 +324,122 @@ func (h *Handler) SearchUsers(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// ActivateUser godoc
+// @Security 	 BasicAuth
+// @Summary      Activate user
+// @Description  Activate a pending, suspended or inactive user
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param id path string true "User ID"
+// @Success 200 {object} models.User
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /admin/users/{id}/activate [post]
+func (h *Handler) ActivateUser(c *gin.Context) {
+	h.setUserStatus(c, entity.UserStatusActive, nil, nil)
+}
+
+// DeactivateUser godoc
+// @Security 	 BasicAuth
+// @Summary      Deactivate user
+// @Description  Deactivate a user until an admin activates them again
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param id path string true "User ID"
+// @Param request body models.DeactivateUserRequest false "Deactivate user request"
+// @Success 200 {object} models.User
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /admin/users/{id}/deactivate [post]
+func (h *Handler) DeactivateUser(c *gin.Context) {
+	var req models.DeactivateUserRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			outerr.BadRequest(c, err.Error())
+			return
+		}
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.setUserStatus(c, entity.UserStatusInactive, req.Reason, nil)
+}
+
+// SuspendUser godoc
+// @Security 	 BasicAuth
+// @Summary      Suspend user
+// @Description  Suspend a user with a reason, until the given time or indefinitely
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param id path string true "User ID"
+// @Param request body models.SuspendUserRequest true "Suspend user request"
+// @Success 200 {object} models.User
+// @Failure 400 {object} outerr.ErrorResponse
+// @Failure 404 {object} outerr.ErrorResponse
+// @Failure 500 {object} outerr.ErrorResponse
+// @Router /admin/users/{id}/suspend [post]
+func (h *Handler) SuspendUser(c *gin.Context) {
+	var req models.SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		outerr.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	if req.Until != nil && !req.Until.After(time.Now()) {
+		outerr.BadRequest(c, "until must be in the future")
+		return
+	}
+
+	h.setUserStatus(c, entity.UserStatusSuspended, &req.Reason, req.Until)
+}
+
+func (h *Handler) setUserStatus(c *gin.Context, status entity.UserStatus, reason *string, until *time.Time) {
+	ctx := c.Request.Context()
+
+	id := c.Param("id")
+	if id == "" {
+		outerr.BadRequest(c, "id is required")
+		return
+	}
+
+	// Admins locking themselves out would need another admin to recover
+	if current := userID(c); current != nil && *current == id && status != entity.UserStatusActive {
+		outerr.BadRequest(c, "you can't change the status of your own account")
+		return
+	}
+
+	before, err := h.userService.GetByID(ctx, id)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	user, err := h.userService.SetStatus(ctx, id, status, reason, until)
+	if err != nil {
+		outerr.HandleError(c, err)
+		return
+	}
+
+	h.audit(c, auditEntry{
+		action:     entity.AuditActionUserStatusChanged,
+		targetType: entity.AuditTargetUser,
+		targetID:   id,
+		changes:    audit.Diff(before, user),
+	})
+
+	c.JSON(http.StatusOK, converters.UserEntityToUserDTO(user))
+}
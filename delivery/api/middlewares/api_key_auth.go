@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"strings"
+
+	"github.com/AsaHero/whereismycity/delivery/api/outerr"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/service/apikeys"
+	"github.com/AsaHero/whereismycity/internal/service/auth"
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+func APIKeyAuth(apiKeyService apikeys.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			outerr.Unauthorized(c, APIKeyHeader+" header is required")
+			c.Abort()
+			return
+		}
+
+		apiKey, user, err := apiKeyService.Authenticate(c, key)
+		if err != nil {
+			outerr.HandleError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Set("user", user)
+		c.Set("role", string(user.Role))
+		c.Set("user_id", user.ID)
+		c.Set("api_key", apiKey)
+
+		c.Next()
+	}
+}
+
+// AnyAuth accepts an API key, a bearer token or basic credentials, whichever the request carries
+func AnyAuth(authService auth.AuthService, apiKeyService apikeys.Service, secret string) gin.HandlerFunc {
+	apiKeyAuth := APIKeyAuth(apiKeyService)
+	bearerAuth := BearerAuth(authService, secret)
+	basicAuth := BasicAuth(authService)
+
+	return func(c *gin.Context) {
+		switch {
+		case c.GetHeader(APIKeyHeader) != "":
+			apiKeyAuth(c)
+		case strings.HasPrefix(c.GetHeader("Authorization"), "Bearer "):
+			bearerAuth(c)
+		default:
+			basicAuth(c)
+		}
+	}
+}
+
+// ScopeRequired rejects API keys lacking the scope, other authentication methods pass through
+func ScopeRequired(scope entity.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, exists := c.Get("api_key")
+		if !exists {
+			c.Next()
+			return
+		}
+
+		apiKey, ok := value.(*entity.APIKeys)
+		if !ok || !apiKey.HasScope(scope) {
+			outerr.Forbidden(c, "API key is missing the "+string(scope)+" scope")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,7 +1,10 @@
 package middlewares
 
 import (
+	"errors"
+
 	"github.com/AsaHero/whereismycity/delivery/api/outerr"
+	"github.com/AsaHero/whereismycity/internal/inerr"
 	"github.com/AsaHero/whereismycity/internal/service/auth"
 	"github.com/gin-gonic/gin"
 )
@@ -19,7 +22,12 @@ func BasicAuth(authService auth.AuthService) gin.HandlerFunc {
 
 		user, err := authService.LoginByUsername(c, username, password)
 		if err != nil {
-			outerr.Unauthorized(c, err.Error())
+			// Blocked accounts get a 403 telling why, anything else is bad credentials
+			if errors.Is(err, inerr.ErrorUserInactive) || errors.Is(err, inerr.ErrorUserSuspended) || errors.Is(err, inerr.ErrorUserPending) {
+				outerr.HandleError(c, err)
+			} else {
+				outerr.Unauthorized(c, err.Error())
+			}
 			c.Abort()
 			return
 		}
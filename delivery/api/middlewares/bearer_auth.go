@@ -5,11 +5,13 @@ import (
 
 	"github.com/AsaHero/whereismycity/delivery/api/outerr"
 	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/service/auth"
 	"github.com/AsaHero/whereismycity/pkg/security"
 	"github.com/gin-gonic/gin"
 )
 
-func BearerAuth(secret string) gin.HandlerFunc {
+// BearerAuth validates the access token and loads its user, rejecting users that may no longer authenticate
+func BearerAuth(authService auth.AuthService, secret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Extract the token from the Authorization header.
 		authHeader := c.GetHeader("Authorization")
@@ -42,7 +44,27 @@ func BearerAuth(secret string) gin.HandlerFunc {
 			return
 		}
 
-		c.Set("user_id", claims.UserID)
+		user, err := authService.GetActiveUser(c, claims.UserID)
+		if err != nil {
+			if inerr.IsErrNotFound(err) {
+				outerr.Unauthorized(c, "Invalid token")
+			} else {
+				outerr.HandleError(c, err)
+			}
+			c.Abort()
+			return
+		}
+
+		// Logging out everywhere and resetting the password revoke the access tokens issued before
+		if user.IsTokenRevoked(claims.IssuedAt) {
+			outerr.Unauthorized(c, "Token was revoked")
+			c.Abort()
+			return
+		}
+
+		c.Set("user", user)
+		c.Set("role", string(user.Role))
+		c.Set("user_id", user.ID)
 
 		c.Next()
 	}
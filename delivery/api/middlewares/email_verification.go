@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"github.com/AsaHero/whereismycity/delivery/api/outerr"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/service/users"
+	"github.com/gin-gonic/gin"
+)
+
+// EmailVerificationRequired rejects users with an unverified email, it passes everyone through when disabled
+func EmailVerificationRequired(userService users.Service, enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled {
+			c.Next()
+			return
+		}
+
+		var user *entity.Users
+		if value, ok := c.Get("user"); ok {
+			user, _ = value.(*entity.Users)
+		}
+
+		// Fall back to loading the user when only its ID is known
+		if user == nil {
+			userID := c.GetString("user_id")
+			if userID == "" {
+				outerr.Unauthorized(c, "Unauthorized")
+				c.Abort()
+				return
+			}
+
+			var err error
+			user, err = userService.GetByID(c, userID)
+			if err != nil {
+				outerr.HandleError(c, err)
+				c.Abort()
+				return
+			}
+		}
+
+		if !user.IsEmailVerified() {
+			outerr.HandleError(c, inerr.ErrorEmailNotVerified)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
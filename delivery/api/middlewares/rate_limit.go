@@ -0,0 +1,114 @@
+package middlewares
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/AsaHero/whereismycity/delivery/api/outerr"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/ratelimit"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit applies the policy limit of the caller's role to its bucket.
+// Requests with an API key have a bucket per key, other authenticated requests one per user
+// and anonymous requests one per IP. Callers without a role are limited as regular users.
+// A nil store disables rate limiting
+func RateLimit(store ratelimit.Store, policy ratelimit.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if store == nil {
+			c.Next()
+			return
+		}
+
+		limit, key := rateLimitBucket(c, policy)
+		if !allow(c, store, limit, key) {
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AuthRateLimit limits the requests of an IP before authentication runs. Failed Basic auth and
+// API key attempts cost a bcrypt or database lookup and never reach the per caller buckets
+func AuthRateLimit(store ratelimit.Store, policy ratelimit.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if store == nil {
+			c.Next()
+			return
+		}
+
+		if !allow(c, store, policy.AuthIP, "auth_ip:"+c.ClientIP()) {
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// allow takes a token from the bucket and sets the rate limit headers,
+// answering 429 and reporting false when the bucket is empty
+func allow(c *gin.Context, store ratelimit.Store, limit ratelimit.Limit, key string) bool {
+	if limit.Unlimited() {
+		return true
+	}
+
+	result, err := store.Allow(c, key, limit)
+	if err != nil {
+		// Failing open keeps the API available when the store is down
+		logger.Warn(fmt.Sprintf("rate limit store failed: %v", err))
+		return true
+	}
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset.Seconds())))
+
+	if !result.Allowed {
+		c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter.Seconds())))
+		outerr.TooManyRequests(c, "Rate limit exceeded")
+		return false
+	}
+
+	return true
+}
+
+// rateLimitBucket returns the limit and the bucket key of the caller
+func rateLimitBucket(c *gin.Context, policy ratelimit.Policy) (ratelimit.Limit, string) {
+	var userID string
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*entity.Users); ok && u != nil {
+			userID = u.ID
+		}
+	}
+	if userID == "" {
+		userID = c.GetString("user_id")
+	}
+
+	if userID == "" {
+		return policy.Anonymous, "ip:" + c.ClientIP()
+	}
+
+	role := entity.UserRoleUser
+	if r := c.GetString("role"); r != "" {
+		role = entity.UserRole(r)
+	}
+
+	key := "user:" + userID
+	if value, ok := c.Get("api_key"); ok {
+		if apiKey, ok := value.(*entity.APIKeys); ok {
+			key = "api_key:" + apiKey.ID
+		}
+	}
+
+	return policy.For(role), key
+}
+
+func ceilSeconds(s float64) int {
+	return int(math.Ceil(s))
+}
@@ -3,38 +3,35 @@ package middlewares
 import (
 	"github.com/AsaHero/whereismycity/delivery/api/outerr"
 	"github.com/AsaHero/whereismycity/internal/entity"
-	"github.com/AsaHero/whereismycity/internal/service/auth"
+	"github.com/AsaHero/whereismycity/internal/service/rbac"
 	"github.com/gin-gonic/gin"
 )
 
-func RoleRequired(authService auth.AuthService, roles ...string) gin.HandlerFunc {
+// PermissionRequired rejects callers whose role lacks any of the permissions.
+// It relies on the role set by BasicAuth, BearerAuth or APIKeyAuth, so it must run after one of them
+func PermissionRequired(rbacService rbac.Service, permissions ...entity.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userRoleVal, exists := c.Get("role")
-		if !exists {
+		value, _ := c.Get("role")
+		role, ok := value.(string)
+		if !ok || role == "" {
 			outerr.Forbidden(c, "Forbidden")
 			c.Abort()
 			return
 		}
 
-		role, ok := userRoleVal.(string)
-		if !ok {
-			outerr.Forbidden(c, "Forbidden")
-			c.Abort()
-			return
-		}
-
-		hasPermission := false
-		for _, r := range roles {
-			if role == r || role == string(entity.UserRoleAdmin) {
-				hasPermission = true
-				break
+		for _, permission := range permissions {
+			granted, err := rbacService.HasPermission(c, entity.UserRole(role), permission)
+			if err != nil {
+				outerr.HandleError(c, err)
+				c.Abort()
+				return
 			}
-		}
 
-		if !hasPermission {
-			outerr.Forbidden(c, "Forbidden")
-			c.Abort()
-			return
+			if !granted {
+				outerr.Forbidden(c, "missing the "+string(permission)+" permission")
+				c.Abort()
+				return
+			}
 		}
 
 		c.Next()
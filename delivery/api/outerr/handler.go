@@ -12,49 +12,81 @@ import (
 
 // HandleError is the main error handling function that integrates with inerrs
 func HandleError(c *gin.Context, err error) {
+	status, response := Resolve(err)
+	c.JSON(status, response)
+}
+
+// Resolve maps an internal error to its HTTP status and error response
+func Resolve(err error) (int, ErrorResponse) {
 	var validationErrors validator.ValidationErrors
 
 	switch {
 	case errors.Is(err, inerr.ErrorIncorrectPassword):
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
+		return http.StatusUnauthorized, ErrorResponse{
+			Code:    CodeUnauthorized,
+			Message: err.Error(),
+		}
+	case errors.Is(err, inerr.ErrorInvalidAPIKey), errors.Is(err, inerr.ErrorExpiredAPIKey):
+		return http.StatusUnauthorized, ErrorResponse{
+			Code:    CodeUnauthorized,
+			Message: err.Error(),
+		}
+	case errors.Is(err, inerr.ErrorInvalidRefreshToken), errors.Is(err, inerr.ErrorRefreshTokenReused), inerr.IsErrJwtValidation(err):
+		return http.StatusUnauthorized, ErrorResponse{
 			Code:    CodeUnauthorized,
 			Message: err.Error(),
-		})
+		}
+	case errors.Is(err, inerr.ErrorUserInactive), errors.Is(err, inerr.ErrorUserSuspended), errors.Is(err, inerr.ErrorUserPending),
+		errors.Is(err, inerr.ErrorEmailNotVerified):
+		return http.StatusForbidden, ErrorResponse{
+			Code:    CodeForbidden,
+			Message: err.Error(),
+		}
+	case errors.Is(err, inerr.ErrorInvalidVerificationToken), errors.Is(err, inerr.ErrorInvalidResetToken),
+		errors.Is(err, inerr.ErrorUnknownRole), errors.Is(err, inerr.ErrorUnknownPermission), errors.Is(err, inerr.ErrorRolesWriteRequired):
+		return http.StatusBadRequest, ErrorResponse{
+			Code:    CodeBadRequest,
+			Message: err.Error(),
+		}
+	case errors.Is(err, inerr.ErrorEmailAlreadyVerified):
+		return http.StatusConflict, ErrorResponse{
+			Code:    CodeConflict,
+			Message: err.Error(),
+		}
 	case errors.Is(err, inerr.ErrorEmptySearhQuery):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		return http.StatusBadRequest, ErrorResponse{
 			Code:    CodeEmptySearchQuery,
 			Message: err.Error(),
 			Details: "Search query can't be empty",
-		})
+		}
 	case errors.As(err, &validationErrors):
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+		return http.StatusBadRequest, ErrorResponse{
 			Code:    CodeValidation,
 			Message: "Validation failed",
 			Details: formatValidationErrors(validationErrors),
-		})
-		return
+		}
 	case inerr.IsErrNotFound(err):
-		c.JSON(http.StatusNotFound, ErrorResponse{
+		return http.StatusNotFound, ErrorResponse{
 			Code:    CodeNotFound,
 			Message: err.Error(),
-		})
+		}
 
 	case inerr.IsErrConflict(err):
-		c.JSON(http.StatusConflict, ErrorResponse{
+		return http.StatusConflict, ErrorResponse{
 			Code:    CodeConflict,
 			Message: err.Error(),
-		})
+		}
 
 	case inerr.IsErrNoChanges(err):
-		c.JSON(http.StatusNotModified, ErrorResponse{
+		return http.StatusNotModified, ErrorResponse{
 			Code:    CodeNoChanges,
 			Message: err.Error(),
-		})
+		}
 	default:
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
+		return http.StatusInternalServerError, ErrorResponse{
 			Code:    CodeInternalError,
 			Message: err.Error(),
-		})
+		}
 	}
 }
 
@@ -89,7 +121,7 @@ func Forbidden(c *gin.Context, message string) {
 }
 
 func TooManyRequests(c *gin.Context, message string) {
-	c.JSON(http.StatusUnauthorized, ErrorResponse{
+	c.JSON(http.StatusTooManyRequests, ErrorResponse{
 		Code:    CodeTooManyRequests,
 		Message: message,
 	})
@@ -114,6 +146,10 @@ func getValidationErrorMessage(err validator.FieldError) ValidationErrorMessage
 		msg.Message = fmt.Sprintf("The %s field is required", err.Field())
 		msg.Suggestion = "Please provide a value for this field"
 
+	case "required_with":
+		msg.Message = fmt.Sprintf("The %s field is required when %s is provided", err.Field(), err.Param())
+		msg.Suggestion = fmt.Sprintf("Please provide %s together with %s", err.Field(), err.Param())
+
 	case "email":
 		msg.Message = "Invalid email address format"
 		msg.Suggestion = "Please provide a valid email address (e.g., user@example.com)"
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/AsaHero/whereismycity/delivery/api/docs"
 	"github.com/AsaHero/whereismycity/delivery/api/handlers"
 	"github.com/AsaHero/whereismycity/delivery/api/middlewares"
@@ -24,13 +27,19 @@ import (
 // @name           				Authorization
 // @description     			Basic Auth "Authorization: Basic <base64 encoded username:password>"
 
+// @securityDefinitions.apikey 	XApiKeyAuth
+// @in              			header
+// @name           				X-API-Key
+// @description     			API key created under /profile/api-keys
+
 func NewRouter(cfg *config.Config, opt *handlers.HandlerOptions) *gin.Engine {
 	r := gin.Default()
 
 	// CORS configuration
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true
-	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization"}
+	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization", middlewares.APIKeyHeader}
+	config.ExposeHeaders = []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"}
 	r.Use(cors.New(config))
 
 	// Init Validator
@@ -42,40 +51,85 @@ func NewRouter(cfg *config.Config, opt *handlers.HandlerOptions) *gin.Engine {
 	// Set base path /api/v1
 	router := r.Group(middlewares.APIPrefix)
 
+	rateLimit := middlewares.RateLimit(opt.RateLimitStore, opt.RateLimitPolicy)
+	authRateLimit := middlewares.AuthRateLimit(opt.RateLimitStore, opt.RateLimitPolicy)
+
+	// Keeps unverified users out of the route groups listed in the config
+	verified := func(group string) gin.HandlerFunc {
+		restricted := slices.Contains(strings.Split(cfg.EmailVerification.Restrict, ","), group)
+		return middlewares.EmailVerificationRequired(opt.UserService, restricted)
+	}
+
 	// Public routes
-	public := router.Group("/")
+	public := router.Group("/", rateLimit)
 	{
 		public.POST("/auth/register", mainHandler.Register)
 		public.POST("/auth/login", mainHandler.Login)
 		public.POST("/auth/refresh", mainHandler.RefreshToken)
+		public.POST("/auth/logout", mainHandler.Logout)
+		public.POST("/auth/verify-email", mainHandler.VerifyEmail)
+		public.POST("/auth/password/forgot", mainHandler.ForgotPassword)
+		public.POST("/auth/password/reset", mainHandler.ResetPassword)
 		public.GET("/demo", mainHandler.Search)
 		public.POST("/contacts/send", mainHandler.SendContacts)
 	}
 
 	// Bearer protected routes
-	bearerProtected := router.Group("/", middlewares.BearerAuth(cfg.Token.Secret))
+	bearerProtected := router.Group("/", middlewares.BearerAuth(opt.AuthService, cfg.Token.Secret))
 	{
 		bearerProtected.GET("/profile", mainHandler.GetProfile)
 		bearerProtected.PATCH("/profile", mainHandler.PatchProfile)
+		bearerProtected.POST("/profile/logout-everywhere", mainHandler.LogoutEverywhere)
+		bearerProtected.POST("/profile/api-keys", verified("api_keys"), mainHandler.CreateAPIKey)
+		bearerProtected.GET("/profile/api-keys", verified("api_keys"), mainHandler.ListAPIKeys)
+		bearerProtected.DELETE("/profile/api-keys/:id", verified("api_keys"), mainHandler.RevokeAPIKey)
+		bearerProtected.POST("/auth/verify-email/resend", mainHandler.ResendVerificationEmail)
+	}
+
+	// Permission checks take the role from whichever authentication ran before them
+	can := func(permissions ...entity.Permission) gin.HandlerFunc {
+		return middlewares.PermissionRequired(opt.RBACService, permissions...)
 	}
 
-	// Basic protected routes
-	basicProtected := router.Group("/", middlewares.BasicAuth(opt.AuthService))
+	// API key, Bearer or Basic protected routes
+	searchScope := middlewares.ScopeRequired(entity.APIKeyScopeSearch)
+	locationsScope := middlewares.ScopeRequired(entity.APIKeyScopeLocations)
+	searchRead := can(entity.PermissionSearchRead)
+	locationsRead := can(entity.PermissionLocationsRead)
+	anyProtected := router.Group("/", authRateLimit, middlewares.AnyAuth(opt.AuthService, opt.APIKeyService, cfg.Token.Secret), rateLimit, verified("search"))
 	{
-		basicProtected.GET("/search", mainHandler.Search)
+		anyProtected.GET("/search", searchScope, searchRead, mainHandler.Search)
+		anyProtected.POST("/search/batch", searchScope, searchRead, mainHandler.BatchSearch)
+		anyProtected.GET("/autocomplete", searchScope, searchRead, mainHandler.Autocomplete)
+		anyProtected.GET("/reverse", searchScope, searchRead, mainHandler.ReverseGeocode)
+		anyProtected.GET("/locations/:id", locationsScope, locationsRead, mainHandler.GetLocation)
+		anyProtected.GET("/locations/by-geoname/:geoname_id", locationsScope, locationsRead, mainHandler.GetLocationByGeonameID)
 	}
 
-	adminApi := router.Group("/admin", middlewares.BasicAuth(opt.AuthService), middlewares.RoleRequired(opt.AuthService, string(entity.UserRoleAdmin)))
+	adminApi := router.Group("/admin", authRateLimit, middlewares.BasicAuth(opt.AuthService), rateLimit)
 	{
 		// Users
-		adminApi.POST("/users", mainHandler.CreateUser)
-		adminApi.GET("/users/search", mainHandler.SearchUsers)
-		adminApi.GET("/users/:id", mainHandler.GetUser)
-		adminApi.PATCH("/users/:id", mainHandler.PatchUser)
-		adminApi.DELETE("/users/:id", mainHandler.DeleteUser)
+		usersRead := can(entity.PermissionUsersRead)
+		usersWrite := can(entity.PermissionUsersWrite)
+		adminApi.POST("/users", usersWrite, mainHandler.CreateUser)
+		adminApi.GET("/users/search", usersRead, mainHandler.SearchUsers)
+		adminApi.GET("/users/:id", usersRead, mainHandler.GetUser)
+		adminApi.PATCH("/users/:id", usersWrite, mainHandler.PatchUser)
+		adminApi.DELETE("/users/:id", usersWrite, mainHandler.DeleteUser)
+		adminApi.POST("/users/:id/restore", usersWrite, mainHandler.RestoreUser)
+		adminApi.POST("/users/:id/activate", usersWrite, mainHandler.ActivateUser)
+		adminApi.POST("/users/:id/deactivate", usersWrite, mainHandler.DeactivateUser)
+		adminApi.POST("/users/:id/suspend", usersWrite, mainHandler.SuspendUser)
 
 		// Statistics
-		// adminApi.GET("/statistics", mainHandler.GetStatistics)
+		adminApi.GET("/statistics", can(entity.PermissionStatsRead), mainHandler.GetStatistics)
+
+		// Audit
+		adminApi.GET("/audit-logs", can(entity.PermissionAuditRead), mainHandler.GetAuditLogs)
+
+		// Roles
+		adminApi.GET("/roles", can(entity.PermissionRolesRead), mainHandler.ListRoles)
+		adminApi.PUT("/roles/:role/permissions", can(entity.PermissionRolesWrite), mainHandler.SetRolePermissions)
 	}
 
 	// Swagger Route
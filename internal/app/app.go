@@ -4,23 +4,39 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/AsaHero/whereismycity/delivery/api"
 	"github.com/AsaHero/whereismycity/delivery/api/dto/models"
 	"github.com/AsaHero/whereismycity/delivery/api/handlers"
-	"github.com/AsaHero/whereismycity/internal/infrasturcture/embeddings"
+	apikeys_repo "github.com/AsaHero/whereismycity/internal/infrasturcture/repository/apikeys"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/auditlogs"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/locations"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/passwordresets"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/refreshtokens"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/rolepermissions"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/searchlogs"
 	users_repo "github.com/AsaHero/whereismycity/internal/infrasturcture/repository/users"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/transliterator"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/typesense"
+	"github.com/AsaHero/whereismycity/internal/service/apikeys"
+	"github.com/AsaHero/whereismycity/internal/service/audit"
 	"github.com/AsaHero/whereismycity/internal/service/auth"
+	locations_service "github.com/AsaHero/whereismycity/internal/service/locations"
+	"github.com/AsaHero/whereismycity/internal/service/passwordreset"
+	"github.com/AsaHero/whereismycity/internal/service/rbac"
 	"github.com/AsaHero/whereismycity/internal/service/search"
+	"github.com/AsaHero/whereismycity/internal/service/statistics"
 	"github.com/AsaHero/whereismycity/internal/service/users"
+	"github.com/AsaHero/whereismycity/internal/service/verification"
 	"github.com/AsaHero/whereismycity/pkg/bot"
 	"github.com/AsaHero/whereismycity/pkg/config"
 	"github.com/AsaHero/whereismycity/pkg/database/postgres"
+	redis_db "github.com/AsaHero/whereismycity/pkg/database/redis"
 	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/AsaHero/whereismycity/pkg/mailer"
+	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -31,6 +47,10 @@ type App struct {
 	logger *logrus.Logger
 	bot    *bot.Bot
 	db     *gorm.DB
+	redis  *redis.Client
+
+	statisticsService statistics.Service
+	stopUserPurge     context.CancelFunc
 }
 
 func New(cfg *config.Config) (*App, error) {
@@ -50,12 +70,20 @@ func New(cfg *config.Config) (*App, error) {
 	}
 
 	// Inin redis, kafka, grpc, rebbitmq, etc.
+	var redisClient *redis.Client
+	if usesRedis(cfg) {
+		redisClient, err = redis_db.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &App{
 		config: cfg,
 		logger: logger,
 		bot:    bot,
 		db:     db,
+		redis:  redisClient,
 	}, nil
 }
 
@@ -67,7 +95,7 @@ func (a *App) Start() error {
 	}
 
 	// Inin embeddings client
-	embeddingsClient, err := embeddings.New(a.config)
+	embeddingsClient, err := newEmbeddingsClient(a.config, a.redis)
 	if err != nil {
 		return fmt.Errorf("failed to init embeddings client: %w", err)
 	}
@@ -84,21 +112,108 @@ func (a *App) Start() error {
 		return fmt.Errorf("failed to init transliterator client: %w", err)
 	}
 
+	searchDependencyTimeout, err := time.ParseDuration(a.config.Search.DependencyTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to parse search dependency timeout: %w", err)
+	}
+
+	// Init search ranking
+	ranking, err := rankingConfig(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to init search ranking: %w", err)
+	}
+
+	// Init rate limiting
+	rateLimitStore, rateLimitPolicy, err := newRateLimiter(a.config, a.redis)
+	if err != nil {
+		return fmt.Errorf("failed to init rate limiter: %w", err)
+	}
+
+	// Init mailer
+	mailClient, err := mailer.New(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to init mailer: %w", err)
+	}
+
+	// Unverified users would be locked out for good if the verification mail is only logged
+	if strings.TrimSpace(a.config.EmailVerification.Restrict) != "" && a.config.Mailer.Driver == "log" {
+		return fmt.Errorf("email verification restrictions require the smtp or file mailer driver")
+	}
+
+	verificationTTL, err := time.ParseDuration(a.config.EmailVerification.TokenTTL)
+	if err != nil {
+		return fmt.Errorf("failed to parse email verification token ttl: %w", err)
+	}
+
+	passwordResetTTL, err := time.ParseDuration(a.config.PasswordReset.TokenTTL)
+	if err != nil {
+		return fmt.Errorf("failed to parse password reset token ttl: %w", err)
+	}
+
+	permissionCacheTTL, err := time.ParseDuration(a.config.Auth.PermissionCacheTTL)
+	if err != nil {
+		return fmt.Errorf("failed to parse permission cache ttl: %w", err)
+	}
+
 	// Init repo
 	userRepo := users_repo.New(a.db)
 	locationsRepo := locations.New(a.db)
+	searchLogsRepo := searchlogs.New(a.db)
+	apiKeysRepo := apikeys_repo.New(a.db)
+	refreshTokensRepo := refreshtokens.New(a.db)
+	passwordResetsRepo := passwordresets.New(a.db)
+	auditLogsRepo := auditlogs.New(a.db)
+	rolePermissionsRepo := rolepermissions.New(a.db)
 
 	// Init service
-	authService := auth.New(comtextDuration, userRepo)
-	userService := users.New(comtextDuration, userRepo)
-	searchService := search.New(comtextDuration, locationsRepo, embeddingsClient, typesenseClient, transliteratorClient)
+	credentialCache, err := newCredentialCache(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to init credential cache: %w", err)
+	}
+
+	registration, err := registrationStatus(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to init auth: %w", err)
+	}
+
+	// A nil cache must not be passed as a non-nil invalidator
+	var credentialInvalidator users.CredentialInvalidator
+	if credentialCache != nil {
+		credentialInvalidator = credentialCache
+	}
+
+	authService := auth.New(comtextDuration, a.config.Token.Secret, registration, userRepo, refreshTokensRepo, credentialCache)
+	userService := users.New(comtextDuration, userRepo, credentialInvalidator)
+	auditService := audit.New(comtextDuration, auditLogsRepo)
+	rbacService := rbac.New(comtextDuration, permissionCacheTTL, rolePermissionsRepo)
+	apiKeyService := apikeys.New(comtextDuration, apiKeysRepo, userRepo)
+	verificationService := verification.New(comtextDuration, a.config.Token.Secret, verificationTTL, a.config.AppURL, userService, mailClient)
+	passwordResetService := passwordreset.New(comtextDuration, passwordResetTTL, a.config.AppURL, passwordResetsRepo, userRepo, userService, authService, apiKeyService, mailClient)
+	a.statisticsService = statistics.New(comtextDuration, searchLogsRepo)
+	searchService := search.New(comtextDuration, searchDependencyTimeout, ranking, locationsRepo, embeddingsClient, typesenseClient, transliteratorClient, a.statisticsService)
+	locationService := locations_service.New(comtextDuration, locationsRepo)
+
+	// Init background jobs
+	a.stopUserPurge, err = startUserPurge(a.config, userService)
+	if err != nil {
+		return fmt.Errorf("failed to init user purge: %w", err)
+	}
 
 	// Init gin router
 	apiRouter := api.NewRouter(a.config, &handlers.HandlerOptions{
-		Bot:           a.bot,
-		AuthService:   authService,
-		UserService:   userService,
-		SearchService: searchService,
+		Bot:                  a.bot,
+		AuthService:          authService,
+		UserService:          userService,
+		SearchService:        searchService,
+		LocationService:      locationService,
+		StatisticsService:    a.statisticsService,
+		APIKeyService:        apiKeyService,
+		RateLimitStore:       rateLimitStore,
+		RateLimitPolicy:      rateLimitPolicy,
+		VerificationService:  verificationService,
+		PasswordResetService: passwordResetService,
+		AuditService:         auditService,
+		RBACService:          rbacService,
 	})
 
 	a.bot.SendContacts(context.Background(), models.SendContactsRequest{
@@ -120,9 +235,22 @@ func (a *App) Start() error {
 func (a *App) Stop() {
 	a.server.Shutdown(context.Background())
 
+	if a.stopUserPurge != nil {
+		a.stopUserPurge()
+	}
+
+	// Save queued search logs before the database connection is closed
+	if a.statisticsService != nil {
+		a.statisticsService.Close()
+	}
+
 	sqlDB, _ := a.db.DB()
 
 	sqlDB.Close()
 
+	if a.redis != nil {
+		a.redis.Close()
+	}
+
 	a.logger.Writer().Close()
 }
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/service/auth"
+	"github.com/AsaHero/whereismycity/pkg/config"
+)
+
+// newCredentialCache inits the basic auth credential cache, nil when it is disabled
+func newCredentialCache(cfg *config.Config) (*auth.CredentialCache, error) {
+	if cfg.Auth.CredentialCacheTTL == "" {
+		return nil, nil
+	}
+
+	ttl, err := time.ParseDuration(cfg.Auth.CredentialCacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credential cache ttl: %w", err)
+	}
+
+	if ttl <= 0 {
+		return nil, nil
+	}
+
+	size, err := strconv.Atoi(cfg.Auth.CredentialCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credential cache size: %w", err)
+	}
+
+	return auth.NewCredentialCache(ttl, size)
+}
+
+// registrationStatus parses the status given to self-registered users
+func registrationStatus(cfg *config.Config) (entity.UserStatus, error) {
+	switch status := entity.UserStatus(cfg.Auth.RegistrationStatus); status {
+	case "":
+		return entity.UserStatusActive, nil
+	case entity.UserStatusActive, entity.UserStatusPending:
+		return status, nil
+	default:
+		return "", fmt.Errorf("registration status must be active or pending, got %q", cfg.Auth.RegistrationStatus)
+	}
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/embeddings"
+	"github.com/AsaHero/whereismycity/pkg/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// newEmbeddingsClient inits the embeddings client wrapped with the configured cache
+func newEmbeddingsClient(cfg *config.Config, redisClient *redis.Client) (embeddings.Client, error) {
+	client, err := embeddings.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Embeddings.Cache {
+	case "", "none":
+		return client, nil
+	case "memory":
+		size, err := strconv.Atoi(cfg.Embeddings.CacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse embeddings cache size: %w", err)
+		}
+		return embeddings.NewCached(client, embeddings.NewMemoryCache(size)), nil
+	case "redis":
+		if redisClient == nil {
+			return nil, fmt.Errorf("redis embeddings cache requires a redis connection")
+		}
+		ttl, err := time.ParseDuration(cfg.Redis.StorageDeadline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse redis storage deadline: %w", err)
+		}
+		return embeddings.NewCached(client, embeddings.NewRedisCache(redisClient, ttl)), nil
+	default:
+		return nil, fmt.Errorf("unknown embeddings cache %q", cfg.Embeddings.Cache)
+	}
+}
+
+// usesRedis reports whether any configured component needs a redis connection
+func usesRedis(cfg *config.Config) bool {
+	return cfg.Embeddings.Cache == "redis" || cfg.RateLimit.Store == "redis"
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/locations"
+	"github.com/AsaHero/whereismycity/internal/service/importer"
+	"github.com/AsaHero/whereismycity/pkg/config"
+	"github.com/AsaHero/whereismycity/pkg/database/postgres"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+)
+
+// Import loads GeoNames dumps into the locations tables
+func Import(ctx context.Context, cfg *config.Config, opts importer.Options) error {
+	// Init logger
+	log := logger.Init(cfg, cfg.APP+".log")
+	defer log.Writer().Close()
+
+	// Init database
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer sqlDB.Close()
+
+	importerService := importer.New(locations.New(db))
+
+	stats, err := importerService.Import(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("import finished: %d locations created, %d updated, %d rows skipped, %d alternate names",
+		stats.Created, stats.Updated, stats.Skipped, stats.AlternateNames))
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/service/users"
+	"github.com/AsaHero/whereismycity/pkg/config"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+)
+
+// startUserPurge periodically removes users deleted longer than the retention window ago,
+// the returned func stops it and is nil when the purge is disabled
+func startUserPurge(cfg *config.Config, userService users.Service) (context.CancelFunc, error) {
+	if cfg.Users.DeletedRetention == "" {
+		return nil, nil
+	}
+
+	retention, err := time.ParseDuration(cfg.Users.DeletedRetention)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deleted users retention: %w", err)
+	}
+
+	if retention <= 0 {
+		return nil, nil
+	}
+
+	interval, err := time.ParseDuration(cfg.Users.PurgeInterval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse users purge interval: %w", err)
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("users purge interval must be positive, got %q", cfg.Users.PurgeInterval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	purge := func() {
+		purged, err := userService.Purge(ctx, time.Now().Add(-retention))
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to purge deleted users: %v", err))
+			return
+		}
+
+		if purged > 0 {
+			logger.Info(fmt.Sprintf("purged %d deleted users", purged))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		purge()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				purge()
+			}
+		}
+	}()
+
+	return cancel, nil
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/service/search"
+	"github.com/AsaHero/whereismycity/pkg/config"
+)
+
+// rankingConfig parses the search ranking configuration
+func rankingConfig(cfg *config.Config) (search.RankingConfig, error) {
+	ranking := search.DefaultRankingConfig()
+
+	switch strategy := entity.RankingStrategy(cfg.Ranking.Strategy); strategy {
+	case "":
+	case entity.RankingWeighted, entity.RankingTypesense, entity.RankingRRF, entity.RankingMinMax:
+		ranking.Strategy = strategy
+	default:
+		return ranking, fmt.Errorf("unknown ranking strategy %q", cfg.Ranking.Strategy)
+	}
+
+	var err error
+	for _, v := range []struct {
+		name  string
+		value string
+		dest  *float64
+	}{
+		{"vector weight", cfg.Ranking.VectorWeight, &ranking.VectorWeight},
+		{"text weight", cfg.Ranking.TextWeight, &ranking.TextWeight},
+		{"rrf k", cfg.Ranking.RRFK, &ranking.RRFK},
+	} {
+		if v.value == "" {
+			continue
+		}
+		if *v.dest, err = strconv.ParseFloat(v.value, 64); err != nil {
+			return ranking, fmt.Errorf("failed to parse ranking %s: %w", v.name, err)
+		}
+	}
+
+	if cfg.Ranking.RRFWeights != "" {
+		ranking.RRFWeights = nil
+		for _, w := range strings.Split(cfg.Ranking.RRFWeights, ",") {
+			weight, err := strconv.ParseFloat(strings.TrimSpace(w), 64)
+			if err != nil {
+				return ranking, fmt.Errorf("failed to parse ranking rrf weights: %w", err)
+			}
+			ranking.RRFWeights = append(ranking.RRFWeights, weight)
+		}
+	}
+
+	return ranking, nil
+}
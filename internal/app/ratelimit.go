@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/ratelimit"
+	"github.com/AsaHero/whereismycity/pkg/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// newRateLimiter inits the configured rate limit store and policy, a nil store disables rate limiting
+func newRateLimiter(cfg *config.Config, redisClient *redis.Client) (ratelimit.Store, ratelimit.Policy, error) {
+	var policy ratelimit.Policy
+
+	var store ratelimit.Store
+	switch cfg.RateLimit.Store {
+	case "", "none":
+		return nil, policy, nil
+	case "memory":
+		store = ratelimit.NewMemoryStore()
+	case "redis":
+		if redisClient == nil {
+			return nil, policy, fmt.Errorf("redis rate limit store requires a redis connection")
+		}
+		store = ratelimit.NewRedisStore(redisClient)
+	default:
+		return nil, policy, fmt.Errorf("unknown rate limit store %q", cfg.RateLimit.Store)
+	}
+
+	policy.Roles = make(map[entity.UserRole]ratelimit.Limit)
+	for _, v := range []struct {
+		role  entity.UserRole
+		value string
+	}{
+		{entity.UserRoleAdmin, cfg.RateLimit.Admin},
+		{entity.UserRoleUser, cfg.RateLimit.User},
+		{entity.UserRoleGuest, cfg.RateLimit.Guest},
+	} {
+		limit, err := parseLimit(v.value)
+		if err != nil {
+			return nil, policy, fmt.Errorf("failed to parse %s rate limit: %w", v.role, err)
+		}
+		policy.Roles[v.role] = limit
+	}
+
+	limit, err := parseLimit(cfg.RateLimit.Anonymous)
+	if err != nil {
+		return nil, policy, fmt.Errorf("failed to parse anonymous rate limit: %w", err)
+	}
+	policy.Anonymous = limit
+
+	limit, err = parseLimit(cfg.RateLimit.AuthIP)
+	if err != nil {
+		return nil, policy, fmt.Errorf("failed to parse auth ip rate limit: %w", err)
+	}
+	policy.AuthIP = limit
+
+	return store, policy, nil
+}
+
+// parseLimit parses "<requests>/<period>", e.g. 60/1m
+func parseLimit(value string) (ratelimit.Limit, error) {
+	if value == "" {
+		return ratelimit.Limit{}, nil
+	}
+
+	requests, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return ratelimit.Limit{}, fmt.Errorf("expected <requests>/<period>, got %q", value)
+	}
+
+	n, err := strconv.Atoi(requests)
+	if err != nil {
+		return ratelimit.Limit{}, err
+	}
+
+	d, err := time.ParseDuration(period)
+	if err != nil {
+		return ratelimit.Limit{}, err
+	}
+
+	if n <= 0 || d <= 0 {
+		return ratelimit.Limit{}, fmt.Errorf("requests and period must be positive, got %q", value)
+	}
+
+	return ratelimit.Per(n, d), nil
+}
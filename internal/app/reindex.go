@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/embeddings"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/locations"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/typesense"
+	"github.com/AsaHero/whereismycity/internal/service/indexer"
+	"github.com/AsaHero/whereismycity/pkg/config"
+	"github.com/AsaHero/whereismycity/pkg/database/postgres"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+)
+
+// Reindex builds the typesense locations collection from the locations tables
+func Reindex(ctx context.Context, cfg *config.Config, opts indexer.Options) error {
+	// Init logger
+	log := logger.Init(cfg, cfg.APP+".log")
+	defer log.Writer().Close()
+
+	// Init database
+	db, err := postgres.New(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer sqlDB.Close()
+
+	// Init embeddings client. Every location is embedded once, so a cache would only evict the entries search relies on
+	embeddingsClient, err := embeddings.New(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init embeddings client: %w", err)
+	}
+
+	// Init typesense client
+	typesenseClient, err := typesense.New(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init typesense client: %w", err)
+	}
+
+	indexerService := indexer.New(locations.New(db), embeddingsClient, typesenseClient)
+
+	stats, err := indexerService.Reindex(ctx, opts)
+	if err != nil {
+		if stats != nil {
+			logger.Error(fmt.Sprintf("reindex stopped after location %d, run again to resume", stats.LastID))
+		}
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("reindex finished: %d locations indexed, %d failed", stats.Indexed, stats.Failed))
+
+	return nil
+}
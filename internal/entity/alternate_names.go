@@ -5,7 +5,7 @@ import "time"
 type LocationAlternateNames struct {
 	ID              int64 `gorm:"primaryKey"`
 	LocationID      int64
-	GeoNameID       int64
+	GeoNameID       int64 `gorm:"column:geoname_id"`
 	AlternateNameID int64
 	Type            string
 	ISOLanguageCode *string
@@ -16,3 +16,36 @@ type LocationAlternateNames struct {
 	IsHistoric      bool
 	CreatedAt       time.Time
 }
+
+func (LocationAlternateNames) TableName() string {
+	return "location_alternate_names"
+}
+
+type RegionType string
+
+const (
+	RegionTypeState   RegionType = "state"
+	RegionTypeCountry RegionType = "country"
+)
+
+// RegionAlternateNames are alternate names of states and countries. Locations store regions by name,
+// so Name and CountryCode match the state or country and code columns of locations.
+type RegionAlternateNames struct {
+	ID              int64 `gorm:"primaryKey"`
+	GeoNameID       int64 `gorm:"column:geoname_id"`
+	AlternateNameID int64
+	RegionType      RegionType
+	CountryCode     string
+	Name            string
+	ISOLanguageCode string
+	AlternateName   string
+	IsPreferred     bool
+	IsShort         bool
+	IsColloquial    bool
+	IsHistoric      bool
+	CreatedAt       time.Time
+}
+
+func (RegionAlternateNames) TableName() string {
+	return "region_alternate_names"
+}
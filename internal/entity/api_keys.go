@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type APIKeyScope string
+
+const (
+	// APIKeyScopeSearch grants access to search, batch search, autocomplete and reverse geocoding
+	APIKeyScopeSearch APIKeyScope = "search"
+	// APIKeyScopeLocations grants access to location lookups by ID
+	APIKeyScopeLocations APIKeyScope = "locations"
+)
+
+// APIKeyScopesAll lists every scope a key can be granted
+var APIKeyScopesAll = APIKeyScopes{APIKeyScopeSearch, APIKeyScopeLocations}
+
+// APIKeyScopes is stored as a comma separated list
+type APIKeyScopes []APIKeyScope
+
+func (s APIKeyScopes) Value() (driver.Value, error) {
+	values := make([]string, len(s))
+	for i, scope := range s {
+		values[i] = string(scope)
+	}
+	return strings.Join(values, ","), nil
+}
+
+func (s *APIKeyScopes) Scan(src any) error {
+	var value string
+	switch v := src.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	case nil:
+		value = ""
+	default:
+		return fmt.Errorf("unsupported api key scopes type %T", src)
+	}
+
+	*s = APIKeyScopes{}
+	for _, scope := range strings.Split(value, ",") {
+		if scope != "" {
+			*s = append(*s, APIKeyScope(scope))
+		}
+	}
+	return nil
+}
+
+type APIKeys struct {
+	ID     string
+	UserID string
+	Name   string
+	// Prefix is the beginning of the plaintext key, kept so users can tell their keys apart
+	Prefix string
+	// KeyHash is the SHA-256 of the plaintext key, the key itself is never stored
+	KeyHash    string
+	Scopes     APIKeyScopes `gorm:"type:character varying(255)"`
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+}
+
+func (k *APIKeys) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKeys) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+func (k *APIKeys) HasScope(scope APIKeyScope) bool {
+	return slices.Contains(k.Scopes, scope)
+}
@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionUserCreated       AuditAction = "user.created"
+	AuditActionUserUpdated       AuditAction = "user.updated"
+	AuditActionUserDeleted       AuditAction = "user.deleted"
+	AuditActionUserRestored      AuditAction = "user.restored"
+	AuditActionUserStatusChanged AuditAction = "user.status_changed"
+
+	AuditActionLogin                  AuditAction = "auth.login"
+	AuditActionLoginFailed            AuditAction = "auth.login_failed"
+	AuditActionLogout                 AuditAction = "auth.logout"
+	AuditActionLogoutEverywhere       AuditAction = "auth.logout_everywhere"
+	AuditActionPasswordChanged        AuditAction = "auth.password_changed"
+	AuditActionPasswordResetRequested AuditAction = "auth.password_reset_requested"
+	AuditActionPasswordReset          AuditAction = "auth.password_reset"
+
+	AuditActionAPIKeyCreated AuditAction = "api_key.created"
+	AuditActionAPIKeyRevoked AuditAction = "api_key.revoked"
+
+	AuditActionRolePermissionsChanged AuditAction = "role.permissions_changed"
+)
+
+const (
+	AuditTargetUser   = "user"
+	AuditTargetAPIKey = "api_key"
+	AuditTargetRole   = "role"
+)
+
+type AuditLogs struct {
+	ID int64 `gorm:"primaryKey"`
+	// ActorID is nil for anonymous callers, e.g. failed logins
+	ActorID    *string
+	ActorRole  *string
+	Action     AuditAction
+	TargetType string
+	TargetID   *string
+	// Changes holds the before and after values of every changed field as JSON, secrets redacted
+	Changes *string `gorm:"type:jsonb"`
+	// Metadata holds action specific details as JSON
+	Metadata  *string `gorm:"type:jsonb"`
+	IP        string
+	UserAgent string
+	CreatedAt time.Time
+}
+
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type AuditLogFilter struct {
+	ActorID    *string
+	Action     *AuditAction
+	TargetType *string
+	TargetID   *string
+	From       time.Time
+	To         time.Time
+}
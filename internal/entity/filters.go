@@ -12,8 +12,41 @@ type UserFilterOptions struct {
 	Username *string
 	Role     *string
 	Status   *string
+	// Deleted lists only soft deleted users instead of live ones
+	Deleted *bool
 }
 
 type LocationFilterOptions struct {
-	Country *string
+	Country  *string
+	State    *string
+	Code     *string
+	BBox     *BoundingBox
+	Near     *GeoPoint
+	RadiusKm *float64
+}
+
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// BoundingBox crosses the antimeridian when MinLng is greater than MaxLng,
+// it then spans from MinLng east to 180 and from -180 east to MaxLng
+type BoundingBox struct {
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+func (b *BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+// LngRanges splits the box into longitude ranges that don't cross the antimeridian
+func (b *BoundingBox) LngRanges() [][2]float64 {
+	if b.CrossesAntimeridian() {
+		return [][2]float64{{b.MinLng, 180}, {-180, b.MaxLng}}
+	}
+	return [][2]float64{{b.MinLng, b.MaxLng}}
 }
@@ -3,7 +3,11 @@ package entity
 import "time"
 
 type LocationGeoNameIDs struct {
-	LocationID uint `gorm:"primaryKey"`
-	GeoNameID  uint `gorm:"primaryKey"`
+	LocationID int64 `gorm:"primaryKey"`
+	GeoNameID  int64 `gorm:"primaryKey;column:geoname_id"`
 	CreatedAt  time.Time
 }
+
+func (LocationGeoNameIDs) TableName() string {
+	return "location_geoname_ids"
+}
@@ -15,4 +15,13 @@ type Locations struct {
 	VectorDistance  *float32 `gorm:"-"`
 	TextMatchScore  *int64   `gorm:"-"`
 	RankFusionScore *float64 `gorm:"-"`
+	Score           *float64 `gorm:"-"`
+	DistanceMeters  *float64 `gorm:"-"`
+
+	// LocalizedCity is the preferred name of the city in LocalizedLanguage
+	LocalizedCity     *string `gorm:"-"`
+	LocalizedLanguage *string `gorm:"-"`
+	// LocalizedState and LocalizedCountry are the preferred region names in the first requested language they are known in
+	LocalizedState   *string `gorm:"-"`
+	LocalizedCountry *string `gorm:"-"`
 }
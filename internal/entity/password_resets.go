@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type PasswordResets struct {
+	ID     string
+	UserID string
+	// TokenHash is the SHA-256 of the mailed token, the token itself is never stored
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+func (r *PasswordResets) IsUsed() bool {
+	return r.UsedAt != nil
+}
+
+func (r *PasswordResets) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
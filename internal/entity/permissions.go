@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+type Permission string
+
+const (
+	// PermissionSearchRead grants search, batch search, autocomplete and reverse geocoding
+	PermissionSearchRead Permission = "search:read"
+	// PermissionSearchExplain grants the ranking breakdown of search results
+	PermissionSearchExplain Permission = "search:explain"
+	// PermissionLocationsRead grants location lookups by ID
+	PermissionLocationsRead Permission = "locations:read"
+	PermissionUsersRead     Permission = "users:read"
+	PermissionUsersWrite    Permission = "users:write"
+	PermissionStatsRead     Permission = "stats:read"
+	PermissionAuditRead     Permission = "audit:read"
+	PermissionRolesRead     Permission = "roles:read"
+	// PermissionRolesWrite grants editing which permissions each role has
+	PermissionRolesWrite Permission = "roles:write"
+)
+
+// PermissionsAll lists every permission a role can be granted
+var PermissionsAll = []Permission{
+	PermissionSearchRead,
+	PermissionSearchExplain,
+	PermissionLocationsRead,
+	PermissionUsersRead,
+	PermissionUsersWrite,
+	PermissionStatsRead,
+	PermissionAuditRead,
+	PermissionRolesRead,
+	PermissionRolesWrite,
+}
+
+func (p Permission) IsValid() bool {
+	return slices.Contains(PermissionsAll, p)
+}
+
+// RolePermissions grants a permission to every user with the role
+type RolePermissions struct {
+	Role       UserRole   `gorm:"primaryKey"`
+	Permission Permission `gorm:"primaryKey"`
+	CreatedAt  time.Time
+}
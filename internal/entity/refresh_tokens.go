@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// RefreshTokens records every issued refresh token by its jti.
+// Tokens rotated from the same login share a family, replaying a rotated token revokes the family
+type RefreshTokens struct {
+	ID         string
+	FamilyID   string
+	UserID     string
+	ReplacedBy *string
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	// UsedAt is set once the token was exchanged for a new pair
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
+
+func (t *RefreshTokens) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+func (t *RefreshTokens) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
@@ -0,0 +1,111 @@
+package entity
+
+type RankingStrategy string
+
+const (
+	// RankingWeighted blends vector similarity and log-scaled text match with fixed weights
+	RankingWeighted RankingStrategy = "weighted"
+	// RankingTypesense keeps the rank fusion score computed by typesense
+	RankingTypesense RankingStrategy = "typesense"
+	// RankingRRF applies reciprocal rank fusion across the original and transliterated result lists
+	RankingRRF RankingStrategy = "rrf"
+	// RankingMinMax blends vector similarity and text match min-max normalized over the candidates
+	RankingMinMax RankingStrategy = "minmax"
+)
+
+// SearchOptions tune a single search, zero values fall back to the configured defaults
+type SearchOptions struct {
+	Filter  LocationFilterOptions
+	Ranking RankingStrategy
+	// Explain attaches a SearchExplanation to the result
+	Explain bool
+	// UserID is the caller the search is logged for, nil for anonymous searches
+	UserID *string
+}
+
+// SearchExplanation traces how a search result was produced
+type SearchExplanation struct {
+	NormalizedQuery string
+	Transliteration string
+	Strategy        RankingStrategy
+	Subqueries      []SubqueryExplanation
+	Merges          []MergeExplanation
+	// Scores covers every ranked candidate, including the ones cut by the limit
+	Scores []ScoreExplanation
+}
+
+type SubqueryExplanation struct {
+	Query string
+	Error string
+	Hits  []HitExplanation
+}
+
+type HitExplanation struct {
+	LocationID      int64
+	City            string
+	Position        int
+	VectorDistance  *float32
+	TextMatchScore  *int64
+	RankFusionScore *float64
+}
+
+type MergeExplanation struct {
+	LocationID int64
+	Subquery   int
+	Position   int
+	Action     string
+	Updated    []string
+}
+
+type ScoreExplanation struct {
+	LocationID int64
+	City       string
+	Position   int
+	Score      *float64
+	Returned   bool
+}
+
+type DegradedReason string
+
+const (
+	// DegradedReasonTransliteration means only the original query was searched
+	DegradedReasonTransliteration DegradedReason = "transliteration_unavailable"
+	// DegradedReasonEmbeddings means typesense was queried by text only, without vector search
+	DegradedReasonEmbeddings DegradedReason = "embeddings_unavailable"
+)
+
+type SearchResult struct {
+	// Query is the normalized query and Transliteration its transliteration, empty if unavailable
+	Query           string
+	Transliteration string
+	Locations       []*Locations
+	Degraded        bool
+	DegradedReasons []DegradedReason
+	Explanation     *SearchExplanation
+}
+
+// Degrade marks the result as produced by a partial pipeline
+func (r *SearchResult) Degrade(reason DegradedReason) {
+	r.Degraded = true
+	for _, v := range r.DegradedReasons {
+		if v == reason {
+			return
+		}
+	}
+	r.DegradedReasons = append(r.DegradedReasons, reason)
+}
+
+type BatchSearchQuery struct {
+	SearchOptions
+	ID    string
+	Query string
+	Limit uint
+}
+
+type BatchSearchResult struct {
+	SearchResult
+	ID    string
+	Query string
+	Limit uint
+	Err   error
+}
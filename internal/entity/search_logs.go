@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+type SearchLogs struct {
+	ID              int64 `gorm:"primaryKey"`
+	UserID          *string
+	Query           string
+	Transliteration string
+	// Filters holds the applied location filters as JSON
+	Filters       *string `gorm:"type:jsonb"`
+	ResultCount   int
+	TopLocationID *int64
+	LatencyMs     int64
+	Degraded      bool
+	CreatedAt     time.Time
+}
+
+type SearchStatistics struct {
+	From              time.Time
+	To                time.Time
+	TotalSearches     int64
+	PerDay            []*DailySearches
+	TopQueries        []*QuerySearches
+	ZeroResultQueries []*QuerySearches
+	Latency           *LatencyPercentiles
+	PerUser           []*UserSearches
+}
+
+type DailySearches struct {
+	Day         time.Time
+	Searches    int64
+	ZeroResults int64
+	Degraded    int64
+}
+
+type QuerySearches struct {
+	Query    string
+	Searches int64
+}
+
+type LatencyPercentiles struct {
+	P50Ms float64 `gorm:"column:p50_ms"`
+	P95Ms float64 `gorm:"column:p95_ms"`
+}
+
+type UserSearches struct {
+	// UserID is nil for anonymous searches
+	UserID   *string
+	Username *string
+	Searches int64
+}
@@ -1,12 +1,23 @@
 package entity
 
-import "time"
+import (
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type UserStatus string
 
 const (
-	UserStatusActive   UserStatus = "active"
+	// UserStatusActive users can authenticate
+	UserStatusActive UserStatus = "active"
+	// UserStatusInactive users were deactivated by an admin and can't authenticate until reactivated
 	UserStatusInactive UserStatus = "inactive"
+	// UserStatusSuspended users can't authenticate until SuspendedUntil, or until reactivated when it is nil
+	UserStatusSuspended UserStatus = "suspended"
+	// UserStatusPending users registered while registration requires approval and wait for an admin to activate them
+	UserStatusPending UserStatus = "pending"
 )
 
 type UserRole string
@@ -17,20 +28,54 @@ const (
 	UserRoleGuest UserRole = "guest"
 )
 
+// UserRolesAll lists every role a user can have
+var UserRolesAll = []UserRole{UserRoleAdmin, UserRoleUser, UserRoleGuest}
+
+func (r UserRole) IsValid() bool {
+	return slices.Contains(UserRolesAll, r)
+}
+
 type Users struct {
-	ID           string
-	Name         string
-	Email        string
-	Username     string
-	Role         UserRole
-	PasswordHash string `gorm:"column:password"`
-	Status       UserStatus
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID              string
+	Name            string
+	Email           string
+	Username        string
+	Role            UserRole
+	PasswordHash    string `gorm:"column:password"`
+	Status          UserStatus
+	StatusReason    *string
+	SuspendedUntil  *time.Time
+	EmailVerifiedAt *time.Time
+	// TokensValidAfter revokes the access tokens issued before it, they can't be tracked one by one
+	TokensValidAfter *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt
 }
 
+// IsActive reports whether the user may authenticate, a suspension ends by itself once its until-date passed
 func (u *Users) IsActive() bool {
-	return u.Status == UserStatusActive
+	switch u.Status {
+	case UserStatusActive:
+		return true
+	case UserStatusSuspended:
+		return u.SuspendedUntil != nil && time.Now().After(*u.SuspendedUntil)
+	default:
+		return false
+	}
+}
+
+// IsTokenRevoked reports whether an access token issued at the given unix time was revoked by a logout everywhere
+func (u *Users) IsTokenRevoked(issuedAt int64) bool {
+	return u.TokensValidAfter != nil && issuedAt < u.TokensValidAfter.Unix()
+}
+
+func (u *Users) IsDeleted() bool {
+	return u.DeletedAt.Valid
+}
+
+func (u *Users) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
 
 func (u *Users) IsAdmin() bool {
@@ -3,8 +3,22 @@ package inerr
 import "errors"
 
 var (
-	ErrorIncorrectPassword = errors.New("incorrect password")
-	ErrorEmptySearhQuery   = errors.New("empty search query")
+	ErrorIncorrectPassword        = errors.New("incorrect password")
+	ErrorEmptySearhQuery          = errors.New("empty search query")
+	ErrorInvalidAPIKey            = errors.New("invalid api key")
+	ErrorExpiredAPIKey            = errors.New("api key expired")
+	ErrorInvalidRefreshToken      = errors.New("invalid refresh token")
+	ErrorRefreshTokenReused       = errors.New("refresh token reuse detected, please log in again")
+	ErrorUserInactive             = errors.New("user is not active")
+	ErrorUserSuspended            = errors.New("user is suspended")
+	ErrorUserPending              = errors.New("user is pending activation")
+	ErrorEmailNotVerified         = errors.New("email is not verified")
+	ErrorEmailAlreadyVerified     = errors.New("email is already verified")
+	ErrorInvalidVerificationToken = errors.New("invalid email verification token")
+	ErrorInvalidResetToken        = errors.New("invalid or expired password reset token")
+	ErrorUnknownRole              = errors.New("unknown role")
+	ErrorUnknownPermission        = errors.New("unknown permission")
+	ErrorRolesWriteRequired       = errors.New("the admin role must keep the roles:write permission")
 )
 
 // error not found
@@ -71,8 +85,11 @@ func (e ErrJwtValidation) Error() string {
 }
 
 func IsErrJwtValidation(err error) bool {
-	_, ok := err.(*ErrJwtValidation)
-	return ok
+	switch err.(type) {
+	case *ErrJwtValidation, ErrJwtValidation:
+		return true
+	}
+	return false
 }
 
 func NewErrJwtValidation(message string) *ErrJwtValidation {
@@ -0,0 +1,45 @@
+package embeddings
+
+import (
+	"context"
+	"sync"
+
+	"github.com/AsaHero/whereismycity/pkg/lru"
+)
+
+// memoryCache is an in-process least recently used cache holding at most size vectors
+type memoryCache struct {
+	mu    sync.Mutex
+	items *lru.Cache[string, []float64]
+}
+
+func NewMemoryCache(size int) Cache {
+	return &memoryCache{
+		items: lru.New[string, []float64](size, nil),
+	}
+}
+
+func (c *memoryCache) Get(ctx context.Context, keys []string) ([][]float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([][]float64, len(keys))
+	for i, key := range keys {
+		if vector, ok := c.items.Get(key); ok {
+			result[i] = vector
+		}
+	}
+
+	return result, nil
+}
+
+func (c *memoryCache) Set(ctx context.Context, entries map[string][]float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, vector := range entries {
+		c.items.Set(key, vector)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisCache shares vectors between instances, expiring them after ttl
+type redisCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewRedisCache(client *redis.Client, ttl time.Duration) Cache {
+	return &redisCache{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (c *redisCache) Get(ctx context.Context, keys []string) ([][]float64, error) {
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]float64, len(keys))
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		vector, err := decodeVector([]byte(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cached vector %s: %w", keys[i], err)
+		}
+		result[i] = vector
+	}
+
+	return result, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, entries map[string][]float64) error {
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, vector := range entries {
+			pipe.Set(ctx, key, encodeVector(vector), c.ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// encodeVector packs a vector as little endian float64 values
+func encodeVector(vector []float64) []byte {
+	data := make([]byte, 8*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint64(data[i*8:], math.Float64bits(v))
+	}
+	return data
+}
+
+func decodeVector(data []byte) ([]float64, error) {
+	if len(data)%8 != 0 {
+		return nil, errors.New("length is not a multiple of 8")
+	}
+
+	vector := make([]float64, len(data)/8)
+	for i := range vector {
+		vector[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return vector, nil
+}
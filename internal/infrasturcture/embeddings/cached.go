@@ -0,0 +1,107 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/AsaHero/whereismycity/pkg/logger"
+)
+
+// cachedClient serves vectors from a cache and only asks the wrapped client for misses.
+// Cache failures are logged and never fail a request.
+type cachedClient struct {
+	client Client
+	cache  Cache
+}
+
+func NewCached(client Client, cache Cache) Client {
+	return &cachedClient{
+		client: client,
+		cache:  cache,
+	}
+}
+
+func (c *cachedClient) Generate(ctx context.Context, text string) ([]float64, error) {
+	vectors, err := c.GenerateBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	return vectors[0], nil
+}
+
+func (c *cachedClient) GenerateBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	if len(texts) == 0 {
+		return [][]float64{}, nil
+	}
+
+	keys := make([]string, len(texts))
+	for i, text := range texts {
+		keys[i] = c.key(text)
+	}
+
+	result, err := c.cache.Get(ctx, keys)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("failed to read embeddings cache: %v", err))
+		result = make([][]float64, len(texts))
+	}
+
+	// Collect misses, asking for every distinct key only once
+	var (
+		missTexts []string
+		missKeys  []string
+		seen      = make(map[string]bool)
+	)
+	for i, vector := range result {
+		if vector != nil || seen[keys[i]] {
+			continue
+		}
+		seen[keys[i]] = true
+		missTexts = append(missTexts, texts[i])
+		missKeys = append(missKeys, keys[i])
+	}
+
+	if len(missTexts) == 0 {
+		return result, nil
+	}
+
+	generated, err := c.client.GenerateBatch(ctx, missTexts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string][]float64, len(missKeys))
+	for i, key := range missKeys {
+		entries[key] = generated[i]
+	}
+
+	for i := range result {
+		if result[i] == nil {
+			result[i] = entries[keys[i]]
+		}
+	}
+
+	if err := c.cache.Set(ctx, entries); err != nil {
+		logger.Warn(fmt.Sprintf("failed to write embeddings cache: %v", err))
+	}
+
+	return result, nil
+}
+
+func (c *cachedClient) Dimensions() int {
+	return c.client.Dimensions()
+}
+
+func (c *cachedClient) Model() string {
+	return c.client.Model()
+}
+
+// key identifies a vector by model and normalized text
+func (c *cachedClient) key(text string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+	sum := sha256.Sum256([]byte(normalized))
+	return "embeddings:" + c.client.Model() + ":" + hex.EncodeToString(sum[:])
+}
@@ -3,6 +3,7 @@ package embeddings
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/AsaHero/whereismycity/pkg/config"
@@ -10,37 +11,163 @@ import (
 	"github.com/openai/openai-go/option"
 )
 
+// openAIModelDimensions are the default vector lengths of the OpenAI embedding models
+var openAIModelDimensions = map[string]int{
+	openai.EmbeddingModelTextEmbedding3Small: 1536,
+	openai.EmbeddingModelTextEmbedding3Large: 3072,
+	openai.EmbeddingModelTextEmbeddingAda002: 1536,
+}
+
+// apiClient talks to OpenAI or any server implementing its embeddings API
 type apiClient struct {
-	cfg    *config.Config
-	client *openai.Client
+	cfg        *config.Config
+	client     *openai.Client
+	model      string
+	dimensions int
+	// shortened asks the model for vectors of the configured length instead of its default one
+	shortened bool
 }
 
+// New inits the embeddings client of the configured provider
 func New(cfg *config.Config) (Client, error) {
+	dimensions, err := parseDimensions(cfg.Embeddings.Dimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Embeddings.Provider {
+	case "", "openai":
+		return newAPIClient(cfg, "", dimensions)
+	case "openai_compatible":
+		if cfg.Embeddings.BaseURL == "" {
+			return nil, fmt.Errorf("openai compatible embeddings provider requires a base url")
+		}
+		return newAPIClient(cfg, cfg.Embeddings.BaseURL, dimensions)
+	case "local":
+		return NewLocal(dimensions), nil
+	default:
+		return nil, fmt.Errorf("unknown embeddings provider %q", cfg.Embeddings.Provider)
+	}
+}
+
+func newAPIClient(cfg *config.Config, baseURL string, dimensions int) (Client, error) {
 	timeoutDuration, err := time.ParseDuration(cfg.OpenAI.Timeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse timeout duration: %w", err)
 	}
 
-	client := openai.NewClient(option.WithAPIKey(cfg.OpenAI.APIKey), option.WithRequestTimeout(timeoutDuration))
+	model := cfg.Embeddings.Model
+	if model == "" {
+		model = openai.EmbeddingModelTextEmbedding3Small
+	}
+
+	shortened := dimensions > 0
+	if !shortened {
+		var ok bool
+		if dimensions, ok = openAIModelDimensions[model]; !ok {
+			return nil, fmt.Errorf("embedding dimensions of model %q are unknown, set them explicitly", model)
+		}
+	}
+
+	apiKey := cfg.Embeddings.APIKey
+	if apiKey == "" {
+		apiKey = cfg.OpenAI.APIKey
+	}
+
+	options := []option.RequestOption{option.WithAPIKey(apiKey), option.WithRequestTimeout(timeoutDuration)}
+	if baseURL != "" {
+		options = append(options, option.WithBaseURL(baseURL))
+	}
+
+	client := openai.NewClient(options...)
 	return &apiClient{
-		cfg:    cfg,
-		client: &client,
+		cfg:        cfg,
+		client:     &client,
+		model:      model,
+		dimensions: dimensions,
+		shortened:  shortened,
 	}, nil
 }
 
 func (c *apiClient) Generate(ctx context.Context, text string) ([]float64, error) {
 	response, err := c.client.Embeddings.New(
 		ctx,
-		openai.EmbeddingNewParams{
-			Input: openai.EmbeddingNewParamsInputUnion{
-				OfString: openai.String(text),
-			},
-			Model: openai.EmbeddingModelTextEmbedding3Small,
-		},
+		c.params(openai.EmbeddingNewParamsInputUnion{
+			OfString: openai.String(text),
+		}),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate embedding: %w", err)
 	}
 
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("failed to generate embedding: no vectors returned")
+	}
+
 	return response.Data[0].Embedding, nil
 }
+
+func (c *apiClient) GenerateBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	if len(texts) == 0 {
+		return [][]float64{}, nil
+	}
+
+	response, err := c.client.Embeddings.New(
+		ctx,
+		c.params(openai.EmbeddingNewParamsInputUnion{
+			OfArrayOfStrings: texts,
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embeddings: %w", err)
+	}
+
+	if len(response.Data) != len(texts) {
+		return nil, fmt.Errorf("failed to generate embeddings: expected %d vectors, got %d", len(texts), len(response.Data))
+	}
+
+	// Results are not guaranteed to be ordered, so place them by index
+	result := make([][]float64, len(texts))
+	for _, data := range response.Data {
+		if data.Index < 0 || int(data.Index) >= len(texts) {
+			return nil, fmt.Errorf("failed to generate embeddings: unexpected index %d", data.Index)
+		}
+		result[data.Index] = data.Embedding
+	}
+
+	return result, nil
+}
+
+func (c *apiClient) Dimensions() int {
+	return c.dimensions
+}
+
+func (c *apiClient) Model() string {
+	return c.model
+}
+
+func (c *apiClient) params(input openai.EmbeddingNewParamsInputUnion) openai.EmbeddingNewParams {
+	params := openai.EmbeddingNewParams{
+		Input: input,
+		Model: c.model,
+	}
+
+	if c.shortened {
+		params.Dimensions = openai.Int(int64(c.dimensions))
+	}
+
+	return params
+}
+
+func parseDimensions(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	dimensions, err := strconv.Atoi(value)
+	if err != nil || dimensions <= 0 {
+		return 0, fmt.Errorf("invalid embedding dimensions %q", value)
+	}
+
+	return dimensions, nil
+}
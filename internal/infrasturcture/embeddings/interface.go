@@ -4,4 +4,16 @@ import "context"
 
 type Client interface {
 	Generate(ctx context.Context, text string) ([]float64, error)
+	GenerateBatch(ctx context.Context, texts []string) ([][]float64, error)
+	// Dimensions is the length of the vectors produced by the model
+	Dimensions() int
+	// Model names the model vectors are generated with
+	Model() string
+}
+
+// Cache stores generated vectors by key
+type Cache interface {
+	// Get returns the cached vector of every key, nil for misses
+	Get(ctx context.Context, keys []string) ([][]float64, error)
+	Set(ctx context.Context, entries map[string][]float64) error
 }
@@ -0,0 +1,93 @@
+package embeddings
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// localDefaultDimensions is the vector length of the local embedder unless configured otherwise
+const localDefaultDimensions = 256
+
+// localNGramSizes are the character n-gram lengths hashed into a vector
+var localNGramSizes = []int{2, 3, 4}
+
+// localClient embeds texts in process by hashing their character n-grams into a fixed size vector.
+// It needs no network and always returns the same vector for the same text, so similar spellings
+// end up close to each other while the search pipeline runs without an external model.
+type localClient struct {
+	dimensions int
+}
+
+func NewLocal(dimensions int) Client {
+	if dimensions <= 0 {
+		dimensions = localDefaultDimensions
+	}
+
+	return &localClient{
+		dimensions: dimensions,
+	}
+}
+
+func (c *localClient) Generate(ctx context.Context, text string) ([]float64, error) {
+	return c.embed(text), nil
+}
+
+func (c *localClient) GenerateBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	result := make([][]float64, len(texts))
+	for i, text := range texts {
+		result[i] = c.embed(text)
+	}
+
+	return result, nil
+}
+
+func (c *localClient) Dimensions() int {
+	return c.dimensions
+}
+
+func (c *localClient) Model() string {
+	return "local-ngram-" + strconv.Itoa(c.dimensions)
+}
+
+func (c *localClient) embed(text string) []float64 {
+	vector := make([]float64, c.dimensions)
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		// Pad words so that n-grams at word boundaries are distinguished from inner ones
+		runes := []rune(" " + word + " ")
+		for _, n := range localNGramSizes {
+			for i := 0; i+n <= len(runes); i++ {
+				h := fnv.New64a()
+				h.Write([]byte(string(runes[i : i+n])))
+				sum := h.Sum64()
+
+				// The top bit picks the sign so that collisions cancel out instead of piling up
+				sign := 1.0
+				if sum>>63 == 1 {
+					sign = -1.0
+				}
+				vector[sum%uint64(c.dimensions)] += sign
+			}
+		}
+	}
+
+	// Normalize to unit length so cosine distance behaves like for model embeddings
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range vector {
+			vector[i] /= norm
+		}
+	}
+
+	return vector
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+// Limit configures a token bucket refilled at Rate tokens per second up to Burst tokens.
+// The zero Limit means unlimited
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+// Per builds a limit allowing n requests per period, all of which may be spent at once
+func Per(n int, period time.Duration) Limit {
+	return Limit{
+		Rate:  float64(n) / period.Seconds(),
+		Burst: n,
+	}
+}
+
+// Policy holds the limits applied to each caller
+type Policy struct {
+	Roles map[entity.UserRole]Limit
+	// Anonymous applies to unauthenticated callers, keyed by their IP
+	Anonymous Limit
+	// AuthIP applies per IP before authentication, so failed credential checks are throttled too
+	AuthIP Limit
+}
+
+// For returns the limit of a role, unknown roles are unlimited
+func (p Policy) For(role entity.UserRole) Limit {
+	return p.Roles[role]
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is the time until the next request is allowed, zero when allowed
+	RetryAfter time.Duration
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+}
+
+type Store interface {
+	// Allow takes a token from the bucket of key
+	Allow(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// newResult describes a bucket holding tokens after the request was decided
+func newResult(limit Limit, tokens float64, allowed bool) *Result {
+	result := &Result{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+
+	if !allowed {
+		result.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+
+	return result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
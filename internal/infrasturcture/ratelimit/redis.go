@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills and takes from a bucket atomically.
+// It uses the redis clock so that replicas with skewed clocks share buckets correctly
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + tonumber(time[2]) / 1000
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+
+return {allowed, tostring(tokens)}
+`)
+
+// redisStore shares buckets between every instance using the same redis
+type redisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) Store {
+	return &redisStore{
+		client: client,
+	}
+}
+
+func (s *redisStore) Allow(ctx context.Context, key string, limit Limit) (*Result, error) {
+	reply, err := tokenBucketScript.Run(ctx, s.client, []string{"ratelimit:" + key}, limit.Rate, limit.Burst).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reply) != 2 {
+		return nil, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	allowed, ok := reply[0].(int64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	raw, ok := reply[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rate limit tokens: %w", err)
+	}
+
+	return newResult(limit, tokens, allowed == 1), nil
+}
@@ -0,0 +1,10 @@
+package apikeys
+
+import (
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.APIKeys]
+}
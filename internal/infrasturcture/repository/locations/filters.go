@@ -0,0 +1,48 @@
+package locations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+// FilterConditions converts location filter options into FindAll conditions
+func FilterConditions(filterOptions entity.LocationFilterOptions) map[string]any {
+	conditions := make(map[string]any)
+
+	if filterOptions.Country != nil {
+		conditions["LOWER(country) = LOWER(?)"] = *filterOptions.Country
+	}
+
+	if filterOptions.State != nil {
+		conditions["LOWER(state) = LOWER(?)"] = *filterOptions.State
+	}
+
+	if filterOptions.Code != nil {
+		conditions["UPPER(code) = ?"] = strings.ToUpper(*filterOptions.Code)
+	}
+
+	if bbox := filterOptions.BBox; bbox != nil {
+		conditions["lat >= ?"] = bbox.MinLat
+		conditions["lat <= ?"] = bbox.MaxLat
+		if bbox.CrossesAntimeridian() {
+			// Coordinates are validated floats so formatting them is safe
+			conditions[fmt.Sprintf("(lng >= %f OR lng <= %f) = ?", bbox.MinLng, bbox.MaxLng)] = true
+		} else {
+			conditions["lng >= ?"] = bbox.MinLng
+			conditions["lng <= ?"] = bbox.MaxLng
+		}
+	}
+
+	if near := filterOptions.Near; near != nil && filterOptions.RadiusKm != nil {
+		// Haversine distance in meters; coordinates are validated floats so formatting them is safe
+		distance := fmt.Sprintf(
+			"2 * 6371008.8 * ASIN(SQRT(POWER(SIN(RADIANS(lat - %f) / 2), 2) + COS(RADIANS(%f)) * COS(RADIANS(lat)) * POWER(SIN(RADIANS(lng - %f) / 2), 2))) <= ?",
+			near.Lat, near.Lat, near.Lng,
+		)
+		conditions[distance] = *filterOptions.RadiusKm * 1000
+	}
+
+	return conditions
+}
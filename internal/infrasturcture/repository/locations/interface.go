@@ -1,10 +1,19 @@
 package locations
 
 import (
+	"context"
+
 	"github.com/AsaHero/whereismycity/internal/entity"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
 )
 
 type Repository interface {
 	repository.BaseRepository[*entity.Locations]
+	FindByGeonameID(ctx context.Context, geonameID int64, preloads ...string) (*entity.Locations, error)
+	FindIDsByGeonameIDs(ctx context.Context, geonameIDs []int64) (map[int64]int64, error)
+	LinkGeonameIDs(ctx context.Context, links []*entity.LocationGeoNameIDs) error
+	UpsertAlternateNames(ctx context.Context, names []*entity.LocationAlternateNames) error
+	FindNamesInLanguages(ctx context.Context, locationIDs []int64, languages []string) ([]*entity.LocationAlternateNames, error)
+	UpsertRegionNames(ctx context.Context, names []*entity.RegionAlternateNames) error
+	FindRegionNamesInLanguages(ctx context.Context, countryCodes []string, languages []string) ([]*entity.RegionAlternateNames, error)
 }
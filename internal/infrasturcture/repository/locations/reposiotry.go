@@ -1,9 +1,13 @@
 package locations
 
 import (
+	"context"
+
 	"github.com/AsaHero/whereismycity/internal/entity"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+	"github.com/AsaHero/whereismycity/pkg/database/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type repo struct {
@@ -17,3 +21,145 @@ func New(db *gorm.DB) Repository {
 		db:             db,
 	}
 }
+
+// FindByGeonameID finds the location linked to the given GeoNames identifier
+func (r *repo) FindByGeonameID(ctx context.Context, geonameID int64, preloads ...string) (*entity.Locations, error) {
+	db := repository.FromContext(ctx, r.db)
+	var location *entity.Locations
+
+	for _, preload := range preloads {
+		db = db.Preload(preload)
+	}
+
+	err := db.
+		Joins("JOIN location_geoname_ids ON location_geoname_ids.location_id = locations.id").
+		Where("location_geoname_ids.geoname_id = ?", geonameID).
+		First(&location).Error
+	if err != nil {
+		return nil, postgres.Error(err, "FindByGeonameID", &entity.Locations{})
+	}
+
+	return location, nil
+}
+
+// FindIDsByGeonameIDs returns a map of GeoNames identifiers to the IDs of their linked locations
+func (r *repo) FindIDsByGeonameIDs(ctx context.Context, geonameIDs []int64) (map[int64]int64, error) {
+	db := repository.FromContext(ctx, r.db)
+	var links []*entity.LocationGeoNameIDs
+
+	result := make(map[int64]int64, len(geonameIDs))
+	if len(geonameIDs) == 0 {
+		return result, nil
+	}
+
+	if err := db.Where("geoname_id IN ?", geonameIDs).Find(&links).Error; err != nil {
+		return nil, postgres.Error(err, "FindIDsByGeonameIDs", &entity.LocationGeoNameIDs{})
+	}
+
+	for _, link := range links {
+		result[link.GeoNameID] = link.LocationID
+	}
+
+	return result, nil
+}
+
+// LinkGeonameIDs links GeoNames identifiers to locations, ignoring links that already exist
+func (r *repo) LinkGeonameIDs(ctx context.Context, links []*entity.LocationGeoNameIDs) error {
+	db := repository.FromContext(ctx, r.db)
+
+	if len(links) == 0 {
+		return nil
+	}
+
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(links).Error; err != nil {
+		return postgres.Error(err, "LinkGeonameIDs", &entity.LocationGeoNameIDs{})
+	}
+
+	return nil
+}
+
+// UpsertAlternateNames inserts alternate names, updating the ones that already exist by their GeoNames alternate name ID
+func (r *repo) UpsertAlternateNames(ctx context.Context, names []*entity.LocationAlternateNames) error {
+	db := repository.FromContext(ctx, r.db)
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "alternate_name_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"location_id", "geoname_id", "type", "iso_language_code", "alternate_name",
+			"is_preferred", "is_short", "is_colloquial", "is_historic",
+		}),
+	}).Create(names).Error
+	if err != nil {
+		return postgres.Error(err, "UpsertAlternateNames", &entity.LocationAlternateNames{})
+	}
+
+	return nil
+}
+
+// FindNamesInLanguages returns current, non colloquial names of the locations in any of the given lowercase language codes
+func (r *repo) FindNamesInLanguages(ctx context.Context, locationIDs []int64, languages []string) ([]*entity.LocationAlternateNames, error) {
+	db := repository.FromContext(ctx, r.db)
+	var names []*entity.LocationAlternateNames
+
+	if len(locationIDs) == 0 || len(languages) == 0 {
+		return names, nil
+	}
+
+	err := db.
+		Where("location_id IN ?", locationIDs).
+		Where("LOWER(iso_language_code) IN ?", languages).
+		Where("type = ? AND NOT is_historic AND NOT is_colloquial", "name").
+		Find(&names).Error
+	if err != nil {
+		return nil, postgres.Error(err, "FindNamesInLanguages", &entity.LocationAlternateNames{})
+	}
+
+	return names, nil
+}
+
+// UpsertRegionNames inserts state and country alternate names, updating the ones that already exist by their GeoNames alternate name ID
+func (r *repo) UpsertRegionNames(ctx context.Context, names []*entity.RegionAlternateNames) error {
+	db := repository.FromContext(ctx, r.db)
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "alternate_name_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"geoname_id", "region_type", "country_code", "name", "iso_language_code", "alternate_name",
+			"is_preferred", "is_short", "is_colloquial", "is_historic",
+		}),
+	}).Create(names).Error
+	if err != nil {
+		return postgres.Error(err, "UpsertRegionNames", &entity.RegionAlternateNames{})
+	}
+
+	return nil
+}
+
+// FindRegionNamesInLanguages returns current, non colloquial state and country names of the countries in any of the given lowercase language codes
+func (r *repo) FindRegionNamesInLanguages(ctx context.Context, countryCodes []string, languages []string) ([]*entity.RegionAlternateNames, error) {
+	db := repository.FromContext(ctx, r.db)
+	var names []*entity.RegionAlternateNames
+
+	if len(countryCodes) == 0 || len(languages) == 0 {
+		return names, nil
+	}
+
+	err := db.
+		Where("country_code IN ?", countryCodes).
+		Where("LOWER(iso_language_code) IN ?", languages).
+		Where("NOT is_historic AND NOT is_colloquial").
+		Find(&names).Error
+	if err != nil {
+		return nil, postgres.Error(err, "FindRegionNamesInLanguages", &entity.RegionAlternateNames{})
+	}
+
+	return names, nil
+}
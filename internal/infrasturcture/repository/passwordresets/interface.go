@@ -0,0 +1,15 @@
+package passwordresets
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.PasswordResets]
+	// MarkUsed marks an unused reset as used, reporting false when it was already used
+	MarkUsed(ctx context.Context, id string, at time.Time) (bool, error)
+}
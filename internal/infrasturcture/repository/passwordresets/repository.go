@@ -0,0 +1,37 @@
+package passwordresets
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+	"github.com/AsaHero/whereismycity/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.PasswordResets]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.PasswordResets](db),
+		db:             db,
+	}
+}
+
+func (r *repo) MarkUsed(ctx context.Context, id string, at time.Time) (bool, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	// The conditional update keeps a token single-use under concurrent resets
+	result := db.Model(&entity.PasswordResets{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	if result.Error != nil {
+		return false, postgres.Error(result.Error, "MarkUsed", &entity.PasswordResets{})
+	}
+
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,15 @@
+package refreshtokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.RefreshTokens]
+	// MarkUsed marks an unused, unrevoked token as replaced, reporting false when it was already used or revoked
+	MarkUsed(ctx context.Context, id, replacedBy string, at time.Time) (bool, error)
+}
@@ -0,0 +1,37 @@
+package refreshtokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+	"github.com/AsaHero/whereismycity/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.RefreshTokens]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.RefreshTokens](db),
+		db:             db,
+	}
+}
+
+func (r *repo) MarkUsed(ctx context.Context, id, replacedBy string, at time.Time) (bool, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	// The conditional update lets only one of concurrent refreshes with the same token win
+	result := db.Model(&entity.RefreshTokens{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Updates(map[string]any{"used_at": at, "replaced_by": replacedBy})
+	if result.Error != nil {
+		return false, postgres.Error(result.Error, "MarkUsed", &entity.RefreshTokens{})
+	}
+
+	return result.RowsAffected == 1, nil
+}
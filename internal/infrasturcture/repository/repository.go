@@ -31,9 +31,33 @@ type BaseRepository[T any] interface {
 	UpdateDataWhere(ctx context.Context, data map[string]any, filter map[string]any) error
 	Upsert(ctx context.Context, columns []string, e T) error
 	BatchCreate(ctx context.Context, entities []T) error
+	BatchUpsert(ctx context.Context, columns []string, entities []T) error
 	Delete(ctx context.Context, filter map[string]any) error
 }
 
+// ApplyFilter adds FindAll style conditions to a query: time ranges, exact times,
+// postgres.TimeCondition and plain column or expression conditions
+func ApplyFilter(db *gorm.DB, filter map[string]any) *gorm.DB {
+	for key, value := range filter {
+		switch v := value.(type) {
+		case []time.Time: // Handle date range
+			if len(v) == 2 {
+				db = db.Where(key+" BETWEEN ? AND ?", v[0], v[1])
+			}
+		case time.Time: // Handle exact date
+			db = db.Where(key+" = ?", v)
+		case postgres.TimeCondition: // Handle complex date conditions
+			for cond, val := range v {
+				db = db.Where(key+" "+string(cond)+" ?", val)
+			}
+		default: // Handle all other data types
+			db = db.Where(key, value)
+		}
+	}
+
+	return db
+}
+
 type baseRepository[T any] struct {
 	db *gorm.DB
 }
@@ -72,22 +96,7 @@ func (r *baseRepository[T]) FindAll(ctx context.Context, limit, page uint64, ord
 	}
 
 	// Apply filtering, pagination, and find operation
-	for key, value := range filter {
-		switch v := value.(type) {
-		case []time.Time: // Handle date range
-			if len(v) == 2 {
-				db = db.Where(key+" BETWEEN ? AND ?", v[0], v[1])
-			}
-		case time.Time: // Handle exact date
-			db = db.Where(key+" = ?", v)
-		case postgres.TimeCondition: // Handle complex date conditions
-			for cond, val := range v {
-				db = db.Where(key+" "+string(cond)+" ?", val)
-			}
-		default: // Handle all other data types
-			db = db.Where(key, value)
-		}
-	}
+	db = ApplyFilter(db, filter)
 
 	result := db.Find(&results)
 	if result.Error != nil {
@@ -98,22 +107,7 @@ func (r *baseRepository[T]) FindAll(ctx context.Context, limit, page uint64, ord
 	countDB := FromContext(ctx, r.db)
 
 	// Reapply filtering for count
-	for key, value := range filter {
-		switch v := value.(type) {
-		case []time.Time:
-			if len(v) == 2 {
-				countDB = countDB.Where(key+" BETWEEN ? AND ?", v[0], v[1])
-			}
-		case time.Time:
-			countDB = countDB.Where(key+" = ?", v)
-		case postgres.TimeCondition:
-			for cond, val := range v {
-				countDB = countDB.Where(key+" "+string(cond)+" ?", val)
-			}
-		default:
-			countDB = countDB.Where(key, value)
-		}
-	}
+	countDB = ApplyFilter(countDB, filter)
 
 	// Count total records matching the filter
 	var total int64
@@ -199,6 +193,22 @@ func (r *baseRepository[T]) BatchCreate(ctx context.Context, entities []T) error
 	return nil
 }
 
+// BatchUpsert inserts multiple records, updating the given columns of the ones that already exist.
+func (r *baseRepository[T]) BatchUpsert(ctx context.Context, columns []string, entities []T) error {
+	db := FromContext(ctx, r.db)
+
+	var model *T
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(entities).Error; err != nil {
+		return postgres.Error(err, "BatchUpsert", model)
+	}
+
+	return nil
+}
+
 // Then implement the Delete method in the baseRepository struct
 func (r *baseRepository[T]) Delete(ctx context.Context, filter map[string]any) error {
 	db := FromContext(ctx, r.db)
@@ -0,0 +1,14 @@
+package rolepermissions
+
+import (
+	"context"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.RolePermissions]
+	// Replace swaps every permission of the role for the given ones
+	Replace(ctx context.Context, role entity.UserRole, permissions []entity.Permission) error
+}
@@ -0,0 +1,53 @@
+package rolepermissions
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+	"github.com/AsaHero/whereismycity/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.RolePermissions]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.RolePermissions](db),
+		db:             db,
+	}
+}
+
+func (r *repo) Replace(ctx context.Context, role entity.UserRole, permissions []entity.Permission) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		db := repository.FromContext(ctx, r.db)
+
+		if err := db.Where("role = ?", role).Delete(&entity.RolePermissions{}).Error; err != nil {
+			return postgres.Error(err, "Replace.Delete", &entity.RolePermissions{})
+		}
+
+		if len(permissions) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		rows := make([]*entity.RolePermissions, len(permissions))
+		for i, permission := range permissions {
+			rows[i] = &entity.RolePermissions{
+				Role:       role,
+				Permission: permission,
+				CreatedAt:  now,
+			}
+		}
+
+		if err := db.Create(rows).Error; err != nil {
+			return postgres.Error(err, "Replace.Create", &entity.RolePermissions{})
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,16 @@
+package searchlogs
+
+import (
+	"context"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+)
+
+type Repository interface {
+	repository.BaseRepository[*entity.SearchLogs]
+	SearchesPerDay(ctx context.Context, filter map[string]any) ([]*entity.DailySearches, error)
+	TopQueries(ctx context.Context, filter map[string]any, limit int) ([]*entity.QuerySearches, error)
+	LatencyPercentiles(ctx context.Context, filter map[string]any) (*entity.LatencyPercentiles, error)
+	SearchesPerUser(ctx context.Context, filter map[string]any, limit int) ([]*entity.UserSearches, error)
+}
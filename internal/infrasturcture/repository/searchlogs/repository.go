@@ -0,0 +1,94 @@
+package searchlogs
+
+import (
+	"context"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
+	"github.com/AsaHero/whereismycity/pkg/database/postgres"
+	"gorm.io/gorm"
+)
+
+type repo struct {
+	repository.BaseRepository[*entity.SearchLogs]
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repo{
+		BaseRepository: repository.NewBaseRepository[*entity.SearchLogs](db),
+		db:             db,
+	}
+}
+
+// SearchesPerDay counts searches, zero result and degraded searches per day, oldest day first
+func (r *repo) SearchesPerDay(ctx context.Context, filter map[string]any) ([]*entity.DailySearches, error) {
+	db := repository.FromContext(ctx, r.db)
+	var result []*entity.DailySearches
+
+	err := repository.ApplyFilter(db.Model(&entity.SearchLogs{}), filter).
+		Select(`date_trunc('day', search_logs.created_at) AS day,
+			COUNT(*) AS searches,
+			COUNT(*) FILTER (WHERE search_logs.result_count = 0) AS zero_results,
+			COUNT(*) FILTER (WHERE search_logs.degraded) AS degraded`).
+		Group("day").
+		Order("day ASC").
+		Scan(&result).Error
+	if err != nil {
+		return nil, postgres.Error(err, "SearchesPerDay", &entity.SearchLogs{})
+	}
+
+	return result, nil
+}
+
+// TopQueries returns the most frequent queries, compared case insensitively
+func (r *repo) TopQueries(ctx context.Context, filter map[string]any, limit int) ([]*entity.QuerySearches, error) {
+	db := repository.FromContext(ctx, r.db)
+	var result []*entity.QuerySearches
+
+	err := repository.ApplyFilter(db.Model(&entity.SearchLogs{}), filter).
+		Select("LOWER(search_logs.query) AS query, COUNT(*) AS searches").
+		Group("LOWER(search_logs.query)").
+		Order("searches DESC, query ASC").
+		Limit(limit).
+		Scan(&result).Error
+	if err != nil {
+		return nil, postgres.Error(err, "TopQueries", &entity.SearchLogs{})
+	}
+
+	return result, nil
+}
+
+func (r *repo) LatencyPercentiles(ctx context.Context, filter map[string]any) (*entity.LatencyPercentiles, error) {
+	db := repository.FromContext(ctx, r.db)
+	var result entity.LatencyPercentiles
+
+	err := repository.ApplyFilter(db.Model(&entity.SearchLogs{}), filter).
+		Select(`COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY search_logs.latency_ms), 0) AS p50_ms,
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY search_logs.latency_ms), 0) AS p95_ms`).
+		Scan(&result).Error
+	if err != nil {
+		return nil, postgres.Error(err, "LatencyPercentiles", &entity.SearchLogs{})
+	}
+
+	return &result, nil
+}
+
+// SearchesPerUser returns the users with the most searches, anonymous searches grouped together
+func (r *repo) SearchesPerUser(ctx context.Context, filter map[string]any, limit int) ([]*entity.UserSearches, error) {
+	db := repository.FromContext(ctx, r.db)
+	var result []*entity.UserSearches
+
+	err := repository.ApplyFilter(db.Model(&entity.SearchLogs{}), filter).
+		Joins("LEFT JOIN users ON users.id = search_logs.user_id").
+		Select("search_logs.user_id AS user_id, users.username AS username, COUNT(*) AS searches").
+		Group("search_logs.user_id, users.username").
+		Order("searches DESC").
+		Limit(limit).
+		Scan(&result).Error
+	if err != nil {
+		return nil, postgres.Error(err, "SearchesPerUser", &entity.SearchLogs{})
+	}
+
+	return result, nil
+}
@@ -2,6 +2,7 @@ package users
 
 import (
 	"context"
+	"time"
 
 	"github.com/AsaHero/whereismycity/internal/entity"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
@@ -11,4 +12,8 @@ type Repository interface {
 	repository.BaseRepository[*entity.Users]
 	ListByFilters(ctx context.Context, limit, page uint64, filterOptions *entity.UserFilterOptions, sortOptions *entity.SortOptions) (int64, []*entity.Users, error)
 	FindByLogin(ctx context.Context, login string) (*entity.Users, error)
+	// Restore clears the deletion of a soft deleted user, failing with a conflict when its username or email was taken since
+	Restore(ctx context.Context, id string) error
+	// Purge permanently removes users soft deleted before the given time and returns their count
+	Purge(ctx context.Context, before time.Time) (int64, error)
 }
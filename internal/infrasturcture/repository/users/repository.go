@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/AsaHero/whereismycity/internal/entity"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository"
@@ -62,6 +63,11 @@ func applyFilters(db *gorm.DB, filterOptions *entity.UserFilterOptions) *gorm.DB
 		return db
 	}
 
+	// Soft deleted users are excluded by default, the deleted filter swaps them in
+	if filterOptions.Deleted != nil && *filterOptions.Deleted {
+		db = db.Unscoped().Where("deleted_at IS NOT NULL")
+	}
+
 	// Apply specific column filters if provided
 	if filterOptions.Email != nil {
 		db = db.Where("email = ?", *filterOptions.Email)
@@ -132,9 +138,55 @@ func (r *repo) FindByLogin(ctx context.Context, login string) (*entity.Users, er
 	db := repository.FromContext(ctx, r.db)
 	var user *entity.Users
 
-	if err := db.Where("username = ?", login).Or("email = ?", login).First(&user).Error; err != nil {
+	if err := db.Where("username = ? OR email = ?", login, login).First(&user).Error; err != nil {
 		return nil, postgres.Error(err, "FindByLogin", &entity.Users{})
 	}
 
 	return user, nil
 }
+
+func (r *repo) Restore(ctx context.Context, id string) error {
+	db := repository.FromContext(ctx, r.db)
+
+	var user *entity.Users
+	if err := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&user).Error; err != nil {
+		return postgres.Error(err, "Restore", &entity.Users{})
+	}
+
+	// Deleted users release their username and email, someone may have registered them since
+	var taken int64
+	err := db.Model(&entity.Users{}).
+		Where("username = ? OR email = ?", user.Username, user.Email).
+		Count(&taken).Error
+	if err != nil {
+		return postgres.Error(err, "Restore", &entity.Users{})
+	}
+
+	if taken > 0 {
+		return postgres.Error(gorm.ErrDuplicatedKey, "Restore", &entity.Users{})
+	}
+
+	result := db.Unscoped().Model(&entity.Users{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return postgres.Error(result.Error, "Restore", &entity.Users{})
+	}
+
+	if result.RowsAffected == 0 {
+		return postgres.Error(gorm.ErrRecordNotFound, "Restore", &entity.Users{})
+	}
+
+	return nil
+}
+
+func (r *repo) Purge(ctx context.Context, before time.Time) (int64, error) {
+	db := repository.FromContext(ctx, r.db)
+
+	result := db.Unscoped().Where("deleted_at < ?", before).Delete(&entity.Users{})
+	if result.Error != nil {
+		return 0, postgres.Error(result.Error, "Purge", &entity.Users{})
+	}
+
+	return result.RowsAffected, nil
+}
@@ -4,19 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/AsaHero/typesense-go/typesense"
 	"github.com/AsaHero/typesense-go/typesense/api"
+	"github.com/AsaHero/whereismycity/internal/entity"
 	"github.com/AsaHero/whereismycity/pkg/config"
 	"github.com/AsaHero/whereismycity/pkg/logger"
 	"github.com/AsaHero/whereismycity/pkg/utility"
 	"github.com/shogo82148/pointer"
 )
 
+// maxSearchesPerRequest is the default number of searches typesense accepts in one multi search request
+const maxSearchesPerRequest = 50
+
 type apiClient struct {
-	cfg    *config.Config
-	client *typesense.Client
+	cfg                 *config.Config
+	client              *typesense.Client
+	autocompleteTimeout time.Duration
 }
 
 func New(cfg *config.Config) (Client, error) {
@@ -25,6 +31,11 @@ func New(cfg *config.Config) (Client, error) {
 		return nil, fmt.Errorf("failed to parse timeout duration: %w", err)
 	}
 
+	autocompleteTimeout, err := time.ParseDuration(cfg.Typesense.AutocompleteTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse autocomplete timeout duration: %w", err)
+	}
+
 	client := typesense.NewClient(
 		typesense.WithServer(fmt.Sprintf("http://%s:%s", cfg.Typesense.Host, cfg.Typesense.Port)),
 		typesense.WithAPIKey(cfg.Typesense.APIKey),
@@ -34,24 +45,134 @@ func New(cfg *config.Config) (Client, error) {
 	)
 
 	return &apiClient{
-		cfg:    cfg,
-		client: client,
+		cfg:                 cfg,
+		client:              client,
+		autocompleteTimeout: autocompleteTimeout,
 	}, nil
 }
 
 func (c *apiClient) MultiHybridSearchLocations(ctx context.Context, queries []MultiHybridSearchRequest) ([]int64, map[int64]Locations, error) {
+	return c.multiHybridSearch(ctx, queries, nil)
+}
+
+// ExplainHybridSearchLocations works like MultiHybridSearchLocations and also traces every subquery and merge decision
+func (c *apiClient) ExplainHybridSearchLocations(ctx context.Context, queries []MultiHybridSearchRequest) ([]int64, map[int64]Locations, *SearchTrace, error) {
+	trace := &SearchTrace{}
+
+	ids, documents, err := c.multiHybridSearch(ctx, queries, trace)
+	for i := range trace.Subqueries {
+		if i < len(queries) {
+			trace.Subqueries[i].Query = queries[i].Query
+		}
+	}
+
+	return ids, documents, trace, err
+}
+
+func (c *apiClient) multiHybridSearch(ctx context.Context, queries []MultiHybridSearchRequest, trace *SearchTrace) ([]int64, map[int64]Locations, error) {
 	if ctx == nil {
 		return nil, nil, errors.New("context cannot be nil")
 	}
 
-	var searches []api.MultiSearchCollectionParameters
+	searches, err := c.hybridSearches(queries)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := c.performMultiSearch(ctx, searches)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(response.Results) == 0 {
+		return nil, nil, errors.New("no search result sets returned")
+	}
+
+	return mergeSearchResults(response.Results, trace)
+}
+
+func (c *apiClient) BatchHybridSearchLocations(ctx context.Context, groups [][]MultiHybridSearchRequest) ([]HybridSearchResult, error) {
+	if ctx == nil {
+		return nil, errors.New("context cannot be nil")
+	}
+
+	results := make([]HybridSearchResult, len(groups))
+
+	// Pack as many groups as fit into a single multi search request, never splitting a group
+	for start := 0; start < len(groups); {
+		end, count := start, 0
+		for end < len(groups) && (count == 0 || count+len(groups[end]) <= maxSearchesPerRequest) {
+			count += len(groups[end])
+			end++
+		}
+
+		var (
+			searches []api.MultiSearchCollectionParameters
+			invalid  = make(map[int]error)
+		)
+		for i := start; i < end; i++ {
+			groupSearches, err := c.hybridSearches(groups[i])
+			if err != nil {
+				invalid[i] = err
+				continue
+			}
+			searches = append(searches, groupSearches...)
+		}
+
+		var response *api.MultiSearchResult
+		if len(searches) > 0 {
+			var err error
+			response, err = c.performMultiSearch(ctx, searches)
+			if err != nil {
+				for i := start; i < end; i++ {
+					results[i].Err = err
+				}
+				start = end
+				continue
+			}
+		}
+
+		offset := 0
+		for i := start; i < end; i++ {
+			if err, ok := invalid[i]; ok {
+				results[i].Err = err
+				continue
+			}
+
+			n := len(groups[i])
+			if offset+n > len(response.Results) {
+				results[i].Err = errors.New("no search result sets returned")
+				continue
+			}
+
+			ids, documents, err := mergeSearchResults(response.Results[offset:offset+n], nil)
+			results[i] = HybridSearchResult{
+				IDs:       ids,
+				Documents: documents,
+				Err:       err,
+			}
+			offset += n
+		}
+
+		start = end
+	}
+
+	return results, nil
+}
+
+func (c *apiClient) hybridSearches(queries []MultiHybridSearchRequest) ([]api.MultiSearchCollectionParameters, error) {
+	searches := make([]api.MultiSearchCollectionParameters, 0, len(queries))
 	for _, v := range queries {
-		if len(v.Embeddings) == 0 {
-			return nil, nil, errors.New("embeddings cannot be empty")
+		if v.Query == "" {
+			return nil, errors.New("query cannot be empty")
 		}
-		searches = append(searches, c.hybridSearchParams(v.Query, v.Embeddings, v.Limit))
+		searches = append(searches, c.hybridSearchParams(v.Query, v.Embeddings, v.Limit, v.Filter))
 	}
 
+	return searches, nil
+}
+
+func (c *apiClient) performMultiSearch(ctx context.Context, searches []api.MultiSearchCollectionParameters) (*api.MultiSearchResult, error) {
 	searchParams := api.MultiSearchSearchesParameter{
 		Searches: searches,
 	}
@@ -61,20 +182,38 @@ func (c *apiClient) MultiHybridSearchLocations(ctx context.Context, queries []Mu
 
 	response, err := c.client.MultiSearch.Perform(ctxWithTimeout, &api.MultiSearchParams{}, searchParams)
 	if err != nil {
-		return nil, nil, fmt.Errorf("typesense search failed: %w", err)
+		return nil, fmt.Errorf("typesense search failed: %w", err)
 	}
 
-	if len(response.Results) == 0 {
-		return nil, nil, errors.New("no search result sets returned")
-	}
+	return response, nil
+}
 
+// mergeSearchResults dedupes hits of several result sets by location, keeping the best score of each kind.
+// IDs are returned in typesense order: result set by result set, each location at its first appearance.
+// Hits and merge decisions are recorded into trace unless it is nil.
+// It fails only if every result set returned an error.
+func mergeSearchResults(results []api.MultiSearchResultItem, trace *SearchTrace) ([]int64, map[int64]Locations, error) {
 	locationMap := make(map[int64]Locations)
-	idSet := make(map[int64]struct{})
+	locationIDs := make([]int64, 0)
+
+	var lastErr error
+	failed := 0
+
+	for set, result := range results {
+		var subquery *SubqueryTrace
+		if trace != nil {
+			trace.Subqueries = append(trace.Subqueries, SubqueryTrace{})
+			subquery = &trace.Subqueries[len(trace.Subqueries)-1]
+		}
 
-	for _, result := range response.Results {
 		if result.Code != nil && *result.Code != 200 {
 			logger.Warn(fmt.Sprintf("Typesense search warning — code %d: %s",
 				*result.Code, pointer.StringValue(result.Error)))
+			lastErr = fmt.Errorf("typesense search failed with code %d: %s", *result.Code, pointer.StringValue(result.Error))
+			failed++
+			if subquery != nil {
+				subquery.Error = lastErr.Error()
+			}
 			continue
 		}
 
@@ -82,7 +221,7 @@ func (c *apiClient) MultiHybridSearchLocations(ctx context.Context, queries []Mu
 			continue
 		}
 
-		for _, hit := range *result.Hits {
+		for position, hit := range *result.Hits {
 			if hit.Document == nil {
 				continue
 			}
@@ -101,33 +240,127 @@ func (c *apiClient) MultiHybridSearchLocations(ctx context.Context, queries []Mu
 			}
 
 			newLoc := parseLocationFromHit(hit)
+			newLoc.Ranks = map[int]int{set: position + 1}
+
+			if subquery != nil {
+				subquery.Hits = append(subquery.Hits, newLoc)
+			}
+
+			decision := MergeDecision{LocationID: id, Subquery: set, Position: position + 1, Action: "added"}
 
 			// If already exists, merge scores
 			if existing, exists := locationMap[id]; exists {
+				decision.Action = "merged"
+				if _, ranked := existing.Ranks[set]; !ranked {
+					existing.Ranks[set] = position + 1
+				}
 				// Keep better (smaller) vector distance
 				if newLoc.VectorDistance != nil && (existing.VectorDistance == nil || *newLoc.VectorDistance < *existing.VectorDistance) {
 					existing.VectorDistance = newLoc.VectorDistance
+					decision.Updated = append(decision.Updated, "vector_distance")
 				}
 				// Keep higher text match
 				if newLoc.TextMatchScore != nil && (existing.TextMatchScore == nil || *newLoc.TextMatchScore > *existing.TextMatchScore) {
 					existing.TextMatchScore = newLoc.TextMatchScore
+					decision.Updated = append(decision.Updated, "text_match_score")
 				}
 				// Keep higher rank fusion score
 				if newLoc.RankFusionScore != nil && (existing.RankFusionScore == nil || *newLoc.RankFusionScore > *existing.RankFusionScore) {
 					existing.RankFusionScore = newLoc.RankFusionScore
+					decision.Updated = append(decision.Updated, "rank_fusion_score")
 				}
 				locationMap[id] = existing
 			} else {
 				locationMap[id] = newLoc
-				idSet[id] = struct{}{}
+				locationIDs = append(locationIDs, id)
+			}
+
+			if trace != nil {
+				trace.Merges = append(trace.Merges, decision)
 			}
 		}
 	}
 
-	// Extract deduped IDs
-	locationIDs := make([]int64, 0, len(idSet))
-	for id := range idSet {
-		locationIDs = append(locationIDs, id)
+	if len(results) > 0 && failed == len(results) {
+		return nil, nil, lastErr
+	}
+
+	return locationIDs, locationMap, nil
+}
+
+func (c *apiClient) AutocompleteLocations(ctx context.Context, req AutocompleteRequest) ([]Locations, error) {
+	if ctx == nil {
+		return nil, errors.New("context cannot be nil")
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.autocompleteTimeout)
+	defer cancel()
+
+	result, err := c.client.Collection("locations").Documents().Search(ctxWithTimeout, c.autocompleteParams(req))
+	if err != nil {
+		return nil, fmt.Errorf("typesense autocomplete failed: %w", err)
+	}
+
+	locations := make([]Locations, 0)
+	if result.Hits == nil {
+		return locations, nil
+	}
+
+	seen := make(map[int64]struct{})
+	for _, hit := range *result.Hits {
+		if hit.Document == nil {
+			continue
+		}
+
+		loc := parseLocationFromHit(hit)
+		if _, exists := seen[loc.ID]; exists {
+			continue
+		}
+
+		seen[loc.ID] = struct{}{}
+		locations = append(locations, loc)
+	}
+
+	return locations, nil
+}
+
+func (c *apiClient) GeoSearchLocations(ctx context.Context, req GeoSearchRequest) ([]int64, map[int64]Locations, error) {
+	if ctx == nil {
+		return nil, nil, errors.New("context cannot be nil")
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := c.client.Collection("locations").Documents().Search(ctxWithTimeout, c.geoSearchParams(req.Lat, req.Lng, req.Limit))
+	if err != nil {
+		return nil, nil, fmt.Errorf("typesense geo search failed: %w", err)
+	}
+
+	locationMap := make(map[int64]Locations)
+	locationIDs := make([]int64, 0)
+
+	if result.Hits == nil {
+		return locationIDs, locationMap, nil
+	}
+
+	for _, hit := range *result.Hits {
+		if hit.Document == nil {
+			continue
+		}
+
+		loc := parseLocationFromHit(hit)
+		if _, exists := locationMap[loc.ID]; exists {
+			continue
+		}
+
+		// Fall back to computing the distance ourselves if typesense didn't return it
+		if loc.GeoDistanceMeters == nil {
+			loc.GeoDistanceMeters = pointer.Float64(utility.Haversine(req.Lat, req.Lng, loc.Lat, loc.Lng))
+		}
+
+		locationMap[loc.ID] = loc
+		locationIDs = append(locationIDs, loc.ID)
 	}
 
 	return locationIDs, locationMap, nil
@@ -141,8 +374,9 @@ func getStringField(doc map[string]any, key string) string {
 	return ""
 }
 
-func (c *apiClient) hybridSearchParams(query string, embeddings []float64, limit int) api.MultiSearchCollectionParameters {
-	return api.MultiSearchCollectionParameters{
+// hybridSearchParams combines text and vector search; without embeddings it is a text-only search
+func (c *apiClient) hybridSearchParams(query string, embeddings []float64, limit int, filter entity.LocationFilterOptions) api.MultiSearchCollectionParameters {
+	params := api.MultiSearchCollectionParameters{
 		Collection:          pointer.String("locations"),
 		QueryBy:             pointer.String("city, translations, state, country"),
 		QueryByWeights:      pointer.String("5,3,1,1"),
@@ -152,11 +386,100 @@ func (c *apiClient) hybridSearchParams(query string, embeddings []float64, limit
 		TypoTokensThreshold: pointer.Int(1),
 		DropTokensThreshold: pointer.Int(1),
 		FacetBy:             pointer.String("country"),
-		RerankHybridMatches: pointer.Bool(true),
-		SortBy:              pointer.String("_vector_distance:asc, _text_match:desc"),
+		SortBy:              pointer.String("_text_match:desc"),
 		Q:                   pointer.String(query),
-		VectorQuery: pointer.String(fmt.Sprintf("embeddings:([%s], alpha: 0.3, k: 100, distance_threshold:0.30)",
-			utility.FloatSliceToCommaSlice(embeddings))),
+	}
+
+	if len(embeddings) > 0 {
+		params.RerankHybridMatches = pointer.Bool(true)
+		params.SortBy = pointer.String("_vector_distance:asc, _text_match:desc")
+		params.VectorQuery = pointer.String(fmt.Sprintf("embeddings:([%s], alpha: 0.3, k: 100, distance_threshold:0.30)",
+			utility.FloatSliceToCommaSlice(embeddings)))
+	}
+
+	if filterBy := locationFilterBy(filter); filterBy != "" {
+		params.FilterBy = pointer.String(filterBy)
+	}
+
+	return params
+}
+
+// locationFilterBy builds a typesense filter_by expression from location filter options
+func locationFilterBy(filter entity.LocationFilterOptions) string {
+	var conditions []string
+
+	if filter.Country != nil {
+		conditions = append(conditions, fmt.Sprintf("country:=`%s`", escapeFilterValue(*filter.Country)))
+	}
+
+	if filter.State != nil {
+		conditions = append(conditions, fmt.Sprintf("state:=`%s`", escapeFilterValue(*filter.State)))
+	}
+
+	if filter.Code != nil {
+		conditions = append(conditions, fmt.Sprintf("code:=`%s`", escapeFilterValue(strings.ToUpper(*filter.Code))))
+	}
+
+	if bbox := filter.BBox; bbox != nil {
+		// Bounding box is expressed as a polygon of its four corners, one per side of the antimeridian
+		var polygons []string
+		for _, lng := range bbox.LngRanges() {
+			polygons = append(polygons, fmt.Sprintf("location:(%f, %f, %f, %f, %f, %f, %f, %f)",
+				bbox.MinLat, lng[0],
+				bbox.MaxLat, lng[0],
+				bbox.MaxLat, lng[1],
+				bbox.MinLat, lng[1],
+			))
+		}
+		conditions = append(conditions, "("+strings.Join(polygons, " || ")+")")
+	}
+
+	if near := filter.Near; near != nil && filter.RadiusKm != nil {
+		conditions = append(conditions, fmt.Sprintf("location:(%f, %f, %f km)", near.Lat, near.Lng, *filter.RadiusKm))
+	}
+
+	return strings.Join(conditions, " && ")
+}
+
+// escapeFilterValue strips backticks which are used to quote filter values
+func escapeFilterValue(value string) string {
+	return strings.ReplaceAll(value, "`", "")
+}
+
+// autocompleteParams builds a prefix-only text query: no vector search, few typos
+// and only the fields needed to render a suggestion
+func (c *apiClient) autocompleteParams(req AutocompleteRequest) *api.SearchCollectionParams {
+	params := &api.SearchCollectionParams{
+		Q:                    pointer.String(req.Query),
+		QueryBy:              pointer.String("city, translations"),
+		QueryByWeights:       pointer.String("3,1"),
+		IncludeFields:        pointer.String("location_id, city, state, country, code"),
+		PerPage:              pointer.Int(req.Limit),
+		Prefix:               pointer.String("true, true"),
+		NumTypos:             pointer.String("1"),
+		MinLen1typo:          pointer.Int(4),
+		TypoTokensThreshold:  pointer.Int(1),
+		DropTokensThreshold:  pointer.Int(0),
+		PrioritizeExactMatch: pointer.Bool(true),
+		SearchCutoffMs:       pointer.Int(int(c.autocompleteTimeout.Milliseconds() / 2)),
+		UseCache:             pointer.Bool(true),
+		CacheTtl:             pointer.Int(60),
+	}
+
+	if filterBy := locationFilterBy(req.Filter); filterBy != "" {
+		params.FilterBy = pointer.String(filterBy)
+	}
+
+	return params
+}
+
+func (c *apiClient) geoSearchParams(lat, lng float64, limit int) *api.SearchCollectionParams {
+	return &api.SearchCollectionParams{
+		Q:             pointer.String("*"),
+		QueryBy:       pointer.String("city"),
+		ExcludeFields: pointer.String("embeddings"),
+		PerPage:       pointer.Int(limit),
+		SortBy:        pointer.String(fmt.Sprintf("location(%f, %f):asc", lat, lng)),
 	}
 }
 
@@ -200,6 +523,11 @@ func parseLocationFromHit(hit api.SearchResultHit) Locations {
 	if hit.HybridSearchInfo != nil && hit.HybridSearchInfo.RankFusionScore != nil {
 		loc.RankFusionScore = hit.HybridSearchInfo.RankFusionScore
 	}
+	if hit.GeoDistanceMeters != nil {
+		if distance, ok := (*hit.GeoDistanceMeters)["location"]; ok {
+			loc.GeoDistanceMeters = pointer.Float64(float64(distance))
+		}
+	}
 
 	return loc
 }
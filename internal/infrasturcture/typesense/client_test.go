@@ -0,0 +1,173 @@
+package typesense
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AsaHero/typesense-go/typesense/api"
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/pkg/config"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/shogo82148/pointer"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "typesense-test")
+	if err != nil {
+		panic(err)
+	}
+
+	logger.Init(&config.Config{LogLevel: "panic"}, filepath.Join(dir, "typesense.log"))
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+type testHit struct {
+	id             int64
+	textMatch      *int64
+	vectorDistance *float32
+}
+
+func resultSet(hits ...testHit) api.MultiSearchResultItem {
+	result := make([]api.SearchResultHit, len(hits))
+	for i, h := range hits {
+		document := map[string]any{"location_id": float64(h.id)}
+		result[i] = api.SearchResultHit{
+			Document:       &document,
+			TextMatch:      h.textMatch,
+			VectorDistance: h.vectorDistance,
+		}
+	}
+	return api.MultiSearchResultItem{Hits: &result}
+}
+
+func failedSet() api.MultiSearchResultItem {
+	return api.MultiSearchResultItem{Code: pointer.Int64(500), Error: pointer.String("boom")}
+}
+
+func TestMergeSearchResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		results   []api.MultiSearchResultItem
+		wantIDs   []int64
+		wantRanks map[int64]map[int]int
+		wantText  map[int64]int64
+		wantErr   bool
+	}{
+		{
+			name: "keeps typesense order, set by set",
+			results: []api.MultiSearchResultItem{
+				resultSet(testHit{id: 3}, testHit{id: 1}),
+				resultSet(testHit{id: 2}, testHit{id: 4}),
+			},
+			wantIDs: []int64{3, 1, 2, 4},
+		},
+		{
+			name: "a location keeps its first position and collects ranks",
+			results: []api.MultiSearchResultItem{
+				resultSet(testHit{id: 1}, testHit{id: 2}),
+				resultSet(testHit{id: 2}, testHit{id: 5}, testHit{id: 1}),
+			},
+			wantIDs: []int64{1, 2, 5},
+			wantRanks: map[int64]map[int]int{
+				1: {0: 1, 1: 3},
+				2: {0: 2, 1: 1},
+				5: {1: 2},
+			},
+		},
+		{
+			name: "merged locations keep the best text score",
+			results: []api.MultiSearchResultItem{
+				resultSet(testHit{id: 1, textMatch: pointer.Int64(10)}),
+				resultSet(testHit{id: 1, textMatch: pointer.Int64(99)}),
+				resultSet(testHit{id: 1, textMatch: pointer.Int64(50)}),
+			},
+			wantIDs:  []int64{1},
+			wantText: map[int64]int64{1: 99},
+		},
+		{
+			name: "failed sets are skipped",
+			results: []api.MultiSearchResultItem{
+				failedSet(),
+				resultSet(testHit{id: 8}, testHit{id: 9}),
+			},
+			wantIDs: []int64{8, 9},
+		},
+		{
+			name:    "fails when every set failed",
+			results: []api.MultiSearchResultItem{failedSet(), failedSet()},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, documents, err := mergeSearchResults(tt.results, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mergeSearchResults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("mergeSearchResults() ids = %v, want %v", ids, tt.wantIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Fatalf("mergeSearchResults() ids = %v, want %v", ids, tt.wantIDs)
+				}
+			}
+
+			for id, ranks := range tt.wantRanks {
+				got := documents[id].Ranks
+				if len(got) != len(ranks) {
+					t.Errorf("location %d ranks = %v, want %v", id, got, ranks)
+					continue
+				}
+				for set, rank := range ranks {
+					if got[set] != rank {
+						t.Errorf("location %d ranks = %v, want %v", id, got, ranks)
+					}
+				}
+			}
+
+			for id, score := range tt.wantText {
+				if got := pointer.Int64Value(documents[id].TextMatchScore); got != score {
+					t.Errorf("location %d text score = %d, want %d", id, got, score)
+				}
+			}
+		})
+	}
+}
+
+func TestLocationFilterByBBox(t *testing.T) {
+	tests := []struct {
+		name string
+		bbox entity.BoundingBox
+		want string
+	}{
+		{
+			name: "regular box is one polygon",
+			bbox: entity.BoundingBox{MinLng: 10, MinLat: 20, MaxLng: 30, MaxLat: 40},
+			want: "(location:(20.000000, 10.000000, 40.000000, 10.000000, 40.000000, 30.000000, 20.000000, 30.000000))",
+		},
+		{
+			name: "box crossing the antimeridian is split in two",
+			bbox: entity.BoundingBox{MinLng: 170, MinLat: -20, MaxLng: -170, MaxLat: -10},
+			want: "(location:(-20.000000, 170.000000, -10.000000, 170.000000, -10.000000, 180.000000, -20.000000, 180.000000)" +
+				" || location:(-20.000000, -180.000000, -10.000000, -180.000000, -10.000000, -170.000000, -20.000000, -170.000000))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := locationFilterBy(entity.LocationFilterOptions{BBox: &tt.bbox}); got != tt.want {
+				t.Errorf("locationFilterBy() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package typesense
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/AsaHero/typesense-go/typesense"
+	"github.com/AsaHero/typesense-go/typesense/api"
+	"github.com/shogo82148/pointer"
+)
+
+// locationsSchema describes the collection searched by the hybrid, autocomplete and geo queries
+func locationsSchema(dimensions int) *api.CollectionSchema {
+	return &api.CollectionSchema{
+		Name: "locations",
+		Fields: []api.Field{
+			{Name: "location_id", Type: "int64", Sort: pointer.Bool(true)},
+			{Name: "city", Type: "string", Infix: pointer.Bool(true)},
+			{Name: "translations", Type: "string[]", Optional: pointer.Bool(true)},
+			{Name: "state", Type: "string", Facet: pointer.Bool(true)},
+			{Name: "country", Type: "string", Facet: pointer.Bool(true)},
+			{Name: "code", Type: "string", Facet: pointer.Bool(true)},
+			{Name: "location", Type: "geopoint"},
+			{Name: "embeddings", Type: "float[]", NumDim: pointer.Int(dimensions)},
+		},
+		DefaultSortingField: pointer.String("location_id"),
+	}
+}
+
+// EnsureLocationsCollection creates the locations collection if it is missing.
+// With recreate set an existing collection is dropped first. Reports whether the collection was created.
+func (c *apiClient) EnsureLocationsCollection(ctx context.Context, dimensions int, recreate bool) (bool, error) {
+	if ctx == nil {
+		return false, errors.New("context cannot be nil")
+	}
+
+	if dimensions <= 0 {
+		return false, errors.New("embedding dimensions must be positive")
+	}
+
+	existing, err := c.client.Collection("locations").Retrieve(ctx)
+	if err != nil && !isNotFound(err) {
+		return false, fmt.Errorf("failed to retrieve locations collection: %w", err)
+	}
+
+	if existing != nil {
+		if !recreate {
+			if err := checkEmbeddingsDimensions(existing.Fields, dimensions); err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+
+		if _, err := c.client.Collection("locations").Delete(ctx); err != nil {
+			return false, fmt.Errorf("failed to drop locations collection: %w", err)
+		}
+	}
+
+	if _, err := c.client.Collections().Create(ctx, locationsSchema(dimensions)); err != nil {
+		return false, fmt.Errorf("failed to create locations collection: %w", err)
+	}
+
+	return true, nil
+}
+
+// ImportLocations upserts documents in a single bulk request
+func (c *apiClient) ImportLocations(ctx context.Context, documents []LocationDocument) (*ImportResult, error) {
+	if ctx == nil {
+		return nil, errors.New("context cannot be nil")
+	}
+
+	result := &ImportResult{Failures: make(map[string]string)}
+	if len(documents) == 0 {
+		return result, nil
+	}
+
+	docs := make([]any, len(documents))
+	for i := range documents {
+		docs[i] = documents[i]
+	}
+
+	action := api.Upsert
+	responses, err := c.client.Collection("locations").Documents().Import(ctx, docs, &api.ImportDocumentsParams{
+		Action:    &action,
+		BatchSize: pointer.Int(len(docs)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("typesense import failed: %w", err)
+	}
+
+	// Typesense answers with one line per document in the order they were sent
+	for i, response := range responses {
+		if response == nil || i >= len(documents) {
+			continue
+		}
+		if response.Success {
+			result.Imported++
+			continue
+		}
+		result.Failures[documents[i].ID] = response.Error
+	}
+
+	return result, nil
+}
+
+func checkEmbeddingsDimensions(fields []api.Field, dimensions int) error {
+	for _, field := range fields {
+		if field.Name == "embeddings" && field.NumDim != nil && *field.NumDim != dimensions {
+			return fmt.Errorf("locations collection has %d embedding dimensions, model produces %d: recreate the collection",
+				*field.NumDim, dimensions)
+		}
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var httpErr *typesense.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound
+}
@@ -4,4 +4,10 @@ import "context"
 
 type Client interface {
 	MultiHybridSearchLocations(ctx context.Context, queries []MultiHybridSearchRequest) ([]int64, map[int64]Locations, error)
+	ExplainHybridSearchLocations(ctx context.Context, queries []MultiHybridSearchRequest) ([]int64, map[int64]Locations, *SearchTrace, error)
+	BatchHybridSearchLocations(ctx context.Context, groups [][]MultiHybridSearchRequest) ([]HybridSearchResult, error)
+	AutocompleteLocations(ctx context.Context, req AutocompleteRequest) ([]Locations, error)
+	GeoSearchLocations(ctx context.Context, req GeoSearchRequest) ([]int64, map[int64]Locations, error)
+	EnsureLocationsCollection(ctx context.Context, dimensions int, recreate bool) (bool, error)
+	ImportLocations(ctx context.Context, documents []LocationDocument) (*ImportResult, error)
 }
@@ -1,9 +1,30 @@
 package typesense
 
+import "github.com/AsaHero/whereismycity/internal/entity"
+
 type MultiHybridSearchRequest struct {
-	Query      string    `json:"q"`
-	Limit      int       `json:"limit"`
-	Embeddings []float64 `json:"embeddings"`
+	Query      string                       `json:"q"`
+	Limit      int                          `json:"limit"`
+	Embeddings []float64                    `json:"embeddings"`
+	Filter     entity.LocationFilterOptions `json:"filter"`
+}
+
+type HybridSearchResult struct {
+	IDs       []int64
+	Documents map[int64]Locations
+	Err       error
+}
+
+type AutocompleteRequest struct {
+	Query  string                       `json:"q"`
+	Limit  int                          `json:"limit"`
+	Filter entity.LocationFilterOptions `json:"filter"`
+}
+
+type GeoSearchRequest struct {
+	Lat   float64 `json:"lat"`
+	Lng   float64 `json:"lng"`
+	Limit int     `json:"limit"`
 }
 
 type Locations struct {
@@ -18,4 +39,50 @@ type Locations struct {
 	VectorDistance  *float32 `json:"_vector_distance"`
 	TextMatchScore  *int64   `json:"text_match"`
 	RankFusionScore *float64 `json:"_rank_fusion_score"`
+	// Ranks holds the 1-based position of the hit in every merged result set it appeared in, by result set index
+	Ranks map[int]int `json:"-"`
+
+	GeoDistanceMeters *float64 `json:"geo_distance_meters"`
+}
+
+// SearchTrace records the hits of every subquery of a multi search and how they were merged
+type SearchTrace struct {
+	Subqueries []SubqueryTrace
+	Merges     []MergeDecision
+}
+
+type SubqueryTrace struct {
+	Query string
+	Hits  []Locations
+	Error string
+}
+
+type MergeDecision struct {
+	LocationID int64
+	Subquery   int
+	// Position is the 1-based position of the hit in the subquery result
+	Position int
+	// Action is "added" for the first appearance of a location and "merged" for later ones
+	Action string
+	// Updated lists the scores taken over from this hit because they were better
+	Updated []string
+}
+
+// LocationDocument is a document of the locations collection
+type LocationDocument struct {
+	ID           string    `json:"id"`
+	LocationID   int64     `json:"location_id"`
+	City         string    `json:"city"`
+	Translations []string  `json:"translations"`
+	State        string    `json:"state"`
+	Country      string    `json:"country"`
+	Code         string    `json:"code"`
+	Location     []float64 `json:"location"`
+	Embeddings   []float64 `json:"embeddings"`
+}
+
+type ImportResult struct {
+	Imported int
+	// Failures maps the id of every rejected document to the reason typesense gave
+	Failures map[string]string
 }
@@ -0,0 +1,19 @@
+package apikeys
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+type Service interface {
+	// Create issues a new key for the user. The plaintext key is returned only here and is never stored
+	Create(ctx context.Context, userID, name string, scopes entity.APIKeyScopes, expiresAt *time.Time) (*entity.APIKeys, string, error)
+	List(ctx context.Context, userID string) ([]*entity.APIKeys, error)
+	Revoke(ctx context.Context, userID, id string) error
+	// RevokeAll revokes every active key of the user
+	RevokeAll(ctx context.Context, userID string) error
+	// Authenticate resolves a plaintext key to the key and its owner, rejecting revoked and expired keys
+	Authenticate(ctx context.Context, key string) (*entity.APIKeys, *entity.Users, error)
+}
@@ -0,0 +1,154 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/apikeys"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/users"
+	"github.com/AsaHero/whereismycity/internal/service/auth"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/AsaHero/whereismycity/pkg/security"
+	"github.com/google/uuid"
+)
+
+// lastUsedResolution limits how often last_used_at is written for a busy key
+const lastUsedResolution = time.Minute
+
+type service struct {
+	contextTimeout time.Duration
+	apiKeyRepo     apikeys.Repository
+	userRepo       users.Repository
+}
+
+func New(contextTimeout time.Duration, apiKeyRepo apikeys.Repository, userRepo users.Repository) Service {
+	return &service{
+		contextTimeout: contextTimeout,
+		apiKeyRepo:     apiKeyRepo,
+		userRepo:       userRepo,
+	}
+}
+
+func (s *service) Create(ctx context.Context, userID, name string, scopes entity.APIKeyScopes, expiresAt *time.Time) (*entity.APIKeys, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	key, prefix, err := security.GenerateAPIKey()
+	if err != nil {
+		return nil, "", inerr.Err(err)
+	}
+
+	if len(scopes) == 0 {
+		scopes = entity.APIKeyScopesAll
+	}
+
+	apiKey := &entity.APIKeys{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   security.HashAPIKey(key),
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, "", inerr.Err(err)
+	}
+
+	return apiKey, key, nil
+}
+
+func (s *service) List(ctx context.Context, userID string) ([]*entity.APIKeys, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	_, keys, err := s.apiKeyRepo.FindAll(ctx, 0, 1, "created_at DESC", map[string]any{"user_id": userID})
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return keys, nil
+}
+
+func (s *service) Revoke(ctx context.Context, userID, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	// Scoping the lookup by owner makes keys of other users indistinguishable from missing ones
+	apiKey, err := s.apiKeyRepo.FindOne(ctx, map[string]any{"id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+
+	if apiKey.IsRevoked() {
+		return nil
+	}
+
+	return s.apiKeyRepo.UpdateDataWhere(ctx, map[string]any{"revoked_at": time.Now()}, map[string]any{"id": apiKey.ID})
+}
+
+func (s *service) RevokeAll(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	err := s.apiKeyRepo.UpdateDataWhere(ctx,
+		map[string]any{"revoked_at": time.Now()},
+		map[string]any{"user_id": userID, "revoked_at": nil},
+	)
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Authenticate(ctx context.Context, key string) (*entity.APIKeys, *entity.Users, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	apiKey, err := s.apiKeyRepo.FindOne(ctx, map[string]any{"key_hash": security.HashAPIKey(key)})
+	if err != nil {
+		if inerr.IsErrNotFound(err) {
+			return nil, nil, inerr.ErrorInvalidAPIKey
+		}
+		return nil, nil, inerr.Err(err)
+	}
+
+	now := time.Now()
+	if apiKey.IsRevoked() {
+		return nil, nil, inerr.ErrorInvalidAPIKey
+	}
+	if apiKey.IsExpired(now) {
+		return nil, nil, inerr.ErrorExpiredAPIKey
+	}
+
+	user, err := s.userRepo.FindOne(ctx, map[string]any{"id": apiKey.UserID})
+	if err != nil {
+		// Keys of deleted users stay in place until the purge but must not authenticate
+		if inerr.IsErrNotFound(err) {
+			return nil, nil, inerr.ErrorInvalidAPIKey
+		}
+		return nil, nil, inerr.Err(err)
+	}
+
+	if err := auth.CheckStatus(user); err != nil {
+		return nil, nil, err
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedResolution {
+		// A failed bookkeeping write must not reject an otherwise valid request
+		err := s.apiKeyRepo.UpdateDataWhere(ctx, map[string]any{"last_used_at": now}, map[string]any{"id": apiKey.ID})
+		if err != nil {
+			logger.Warn(fmt.Sprintf("failed to update last used time of api key %s: %v", apiKey.ID, err))
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return apiKey, user, nil
+}
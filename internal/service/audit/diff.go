@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+// Redacted replaces the values of secret fields in changes
+const Redacted = "[REDACTED]"
+
+// redactedFields never have their values recorded, only the fact that they changed
+var redactedFields = map[string]bool{
+	"PasswordHash": true,
+	"KeyHash":      true,
+	"TokenHash":    true,
+}
+
+// skippedFields change with every update and carry no information
+var skippedFields = map[string]bool{
+	"UpdatedAt": true,
+}
+
+// Diff returns the changed fields between two values of the same struct type keyed by snake cased names.
+// A nil before records a creation and a nil after a deletion
+func Diff(before, after any) map[string]entity.AuditChange {
+	b, a := structValue(before), structValue(after)
+
+	var t reflect.Type
+	switch {
+	case b.IsValid():
+		t = b.Type()
+	case a.IsValid():
+		t = a.Type()
+	default:
+		return nil
+	}
+
+	changes := make(map[string]entity.AuditChange)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || skippedFields[field.Name] {
+			continue
+		}
+
+		var bv, av any
+		if b.IsValid() {
+			bv = fieldValue(b.Field(i))
+		}
+		if a.IsValid() {
+			av = fieldValue(a.Field(i))
+		}
+
+		if reflect.DeepEqual(bv, av) {
+			continue
+		}
+
+		if redactedFields[field.Name] {
+			bv, av = redact(bv), redact(av)
+		}
+
+		changes[snakeCase(field.Name)] = entity.AuditChange{Before: bv, After: av}
+	}
+
+	return changes
+}
+
+// structValue dereferences pointers to a struct, it is invalid for nil
+func structValue(v any) reflect.Value {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+func fieldValue(v reflect.Value) any {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+func redact(v any) any {
+	if v == nil {
+		return nil
+	}
+	return Redacted
+}
+
+// snakeCase converts field names like EmailVerifiedAt or APIKey to email_verified_at or api_key
+func snakeCase(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word before an upper case letter that follows a lower case one
+			// or ends an acronym
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
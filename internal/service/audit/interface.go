@@ -0,0 +1,13 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+type Service interface {
+	// Record saves an audit entry. Failures are logged, they never fail the audited action
+	Record(ctx context.Context, log *entity.AuditLogs)
+	List(ctx context.Context, filter *entity.AuditLogFilter, limit, page uint64) (uint64, []*entity.AuditLogs, error)
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/auditlogs"
+	"github.com/AsaHero/whereismycity/pkg/database/postgres"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+)
+
+type service struct {
+	contextTimeout time.Duration
+	auditLogRepo   auditlogs.Repository
+}
+
+func New(contextTimeout time.Duration, auditLogRepo auditlogs.Repository) Service {
+	return &service{
+		contextTimeout: contextTimeout,
+		auditLogRepo:   auditLogRepo,
+	}
+}
+
+func (s *service) Record(ctx context.Context, log *entity.AuditLogs) {
+	// The entry is saved even when the caller already went away
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.contextTimeout)
+	defer cancel()
+
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+
+	if err := s.auditLogRepo.Create(ctx, log); err != nil {
+		logger.Error(fmt.Sprintf("failed to save audit log %s: %v", log.Action, err))
+	}
+}
+
+func (s *service) List(ctx context.Context, filter *entity.AuditLogFilter, limit, page uint64) (uint64, []*entity.AuditLogs, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	conditions := map[string]any{
+		"created_at": postgres.TimeCondition{
+			postgres.OpGreaterThanOrEqual: filter.From,
+			postgres.OpLessThan:           filter.To,
+		},
+	}
+	if filter.ActorID != nil {
+		conditions["actor_id = ?"] = *filter.ActorID
+	}
+	if filter.Action != nil {
+		conditions["action = ?"] = string(*filter.Action)
+	}
+	if filter.TargetType != nil {
+		conditions["target_type = ?"] = *filter.TargetType
+	}
+	if filter.TargetID != nil {
+		conditions["target_id = ?"] = *filter.TargetID
+	}
+
+	return s.auditLogRepo.FindAll(ctx, limit, page, "created_at DESC, id DESC", conditions)
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/pkg/lru"
+)
+
+// CredentialCache remembers recently verified username and password pairs so that
+// basic authentication doesn't run bcrypt on every request.
+// Entries are keyed by an HMAC with a per-process secret, so neither passwords nor
+// hashes that could be brute forced offline are kept in memory
+type CredentialCache struct {
+	mu     sync.Mutex
+	secret []byte
+	ttl    time.Duration
+	items  *lru.Cache[string, *credentialEntry]
+	// byUser indexes the cached keys of each user for invalidation
+	byUser map[string]map[string]struct{}
+}
+
+type credentialEntry struct {
+	user      entity.Users
+	expiresAt time.Time
+}
+
+// NewCredentialCache creates a cache holding at most size credentials for ttl each
+func NewCredentialCache(ttl time.Duration, size int) (*CredentialCache, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	c := &CredentialCache{
+		secret: secret,
+		ttl:    ttl,
+		byUser: make(map[string]map[string]struct{}),
+	}
+	c.items = lru.New(size, func(key string, entry *credentialEntry) {
+		c.forget(key, entry.user.ID)
+	})
+
+	return c, nil
+}
+
+func (c *CredentialCache) key(username, password string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(username))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Get returns a copy of the user verified with these credentials, nil when missing or expired
+func (c *CredentialCache) Get(username, password string) *entity.Users {
+	key := c.key(username, password)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.items.Get(key)
+	if !ok {
+		return nil
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		c.remove(key)
+		return nil
+	}
+
+	user := entry.user
+	return &user
+}
+
+// Set caches credentials that were just verified for the user
+func (c *CredentialCache) Set(username, password string, user *entity.Users) {
+	key := c.key(username, password)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(key)
+
+	c.items.Set(key, &credentialEntry{
+		user:      *user,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+
+	if c.byUser[user.ID] == nil {
+		c.byUser[user.ID] = make(map[string]struct{})
+	}
+	c.byUser[user.ID][key] = struct{}{}
+}
+
+// InvalidateUser drops every cached credential of the user, it must be called
+// whenever the password, role or status of the user changes
+func (c *CredentialCache) InvalidateUser(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.byUser[userID] {
+		c.items.Remove(key)
+	}
+	delete(c.byUser, userID)
+}
+
+func (c *CredentialCache) remove(key string) {
+	if entry, ok := c.items.Remove(key); ok {
+		c.forget(key, entry.user.ID)
+	}
+}
+
+// forget drops the key from the index of the user's cached keys
+func (c *CredentialCache) forget(key, userID string) {
+	if keys, ok := c.byUser[userID]; ok {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(c.byUser, userID)
+		}
+	}
+}
@@ -9,5 +9,16 @@ import (
 type AuthService interface {
 	LoginByUsername(ctx context.Context, username, password string) (*entity.Users, error)
 	Login(ctx context.Context, login, password string) (*entity.Users, error)
+	// GetActiveUser loads the user of a token, failing when the user may not authenticate
+	GetActiveUser(ctx context.Context, userID string) (*entity.Users, error)
 	Register(ctx context.Context, name, email, password string) (*entity.Users, error)
+	// IssueTokens starts a new refresh token family for the user
+	IssueTokens(ctx context.Context, userID string) (*entity.TokenPair, error)
+	// RefreshTokens rotates a refresh token, replaying a rotated token revokes its whole family
+	RefreshTokens(ctx context.Context, refreshToken string) (*entity.TokenPair, error)
+	// Logout revokes the family of the refresh token, or every token of its user when everywhere is set.
+	// Revoked tokens are rejected and used ones are treated as a replay. It returns the ID of the user the token belongs to
+	Logout(ctx context.Context, refreshToken string, everywhere bool) (string, error)
+	// LogoutEverywhere revokes every refresh token of the user and the access tokens issued so far
+	LogoutEverywhere(ctx context.Context, userID string) error
 }
@@ -8,24 +8,45 @@ import (
 
 	"github.com/AsaHero/whereismycity/internal/entity"
 	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/refreshtokens"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/users"
 	"github.com/AsaHero/whereismycity/pkg/security"
 	"github.com/google/uuid"
 )
 
 type service struct {
-	contentTimeout time.Duration
-	userRepo       users.Repository
+	contentTimeout     time.Duration
+	secret             string
+	registrationStatus entity.UserStatus
+	userRepo           users.Repository
+	refreshTokenRepo   refreshtokens.Repository
+	credentialCache    *CredentialCache
 }
 
-func New(contentTimeout time.Duration, userRepo users.Repository) AuthService {
+// New creates the auth service, self-registered users get registrationStatus, either active or pending.
+// credentialCache may be nil to verify every basic auth request
+func New(contentTimeout time.Duration, secret string, registrationStatus entity.UserStatus, userRepo users.Repository, refreshTokenRepo refreshtokens.Repository, credentialCache *CredentialCache) AuthService {
 	return &service{
-		contentTimeout: contentTimeout,
-		userRepo:       userRepo,
+		contentTimeout:     contentTimeout,
+		secret:             secret,
+		registrationStatus: registrationStatus,
+		userRepo:           userRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		credentialCache:    credentialCache,
 	}
 }
 
 func (s *service) LoginByUsername(ctx context.Context, username, password string) (*entity.Users, error) {
+	if s.credentialCache != nil {
+		if user := s.credentialCache.Get(username, password); user != nil {
+			// Suspensions may have ended since the credentials were cached
+			if err := CheckStatus(user); err != nil {
+				return nil, err
+			}
+			return user, nil
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, s.contentTimeout)
 	defer cancel()
 
@@ -43,6 +64,15 @@ func (s *service) LoginByUsername(ctx context.Context, username, password string
 		return nil, inerr.ErrorIncorrectPassword
 	}
 
+	// Status is checked after the password so that it isn't revealed to anyone guessing
+	if err := CheckStatus(user); err != nil {
+		return nil, err
+	}
+
+	if s.credentialCache != nil {
+		s.credentialCache.Set(username, password, user)
+	}
+
 	return user, nil
 }
 
@@ -62,6 +92,26 @@ func (s *service) Login(ctx context.Context, login, password string) (*entity.Us
 		return nil, inerr.ErrorIncorrectPassword
 	}
 
+	if err := CheckStatus(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *service) GetActiveUser(ctx context.Context, userID string) (*entity.Users, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contentTimeout)
+	defer cancel()
+
+	user, err := s.userRepo.FindOne(ctx, map[string]any{"id": userID})
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	if err := CheckStatus(user); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -83,7 +133,7 @@ func (s *service) Register(ctx context.Context, name, email, password string) (*
 		Email:        email,
 		PasswordHash: passwordHash,
 		Role:         entity.UserRoleUser,
-		Status:       entity.UserStatusActive,
+		Status:       s.registrationStatus,
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
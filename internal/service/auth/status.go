@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+)
+
+// CheckStatus returns why the user may not authenticate, nil for active users
+func CheckStatus(user *entity.Users) error {
+	if user.IsActive() {
+		return nil
+	}
+
+	switch user.Status {
+	case entity.UserStatusSuspended:
+		err := inerr.ErrorUserSuspended
+		if user.SuspendedUntil != nil {
+			err = fmt.Errorf("%w until %s", err, user.SuspendedUntil.Format("2006-01-02 15:04 MST"))
+		}
+		if user.StatusReason != nil && *user.StatusReason != "" {
+			err = fmt.Errorf("%w: %s", err, *user.StatusReason)
+		}
+		return err
+	case entity.UserStatusPending:
+		return inerr.ErrorUserPending
+	default:
+		return inerr.ErrorUserInactive
+	}
+}
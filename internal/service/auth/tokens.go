@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/AsaHero/whereismycity/pkg/security"
+	"github.com/google/uuid"
+)
+
+func (s *service) IssueTokens(ctx context.Context, userID string) (*entity.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contentTimeout)
+	defer cancel()
+
+	token := newRefreshToken(userID, uuid.New().String())
+	if err := s.refreshTokenRepo.Create(ctx, token); err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return s.tokenPair(userID, token.ID)
+}
+
+func (s *service) RefreshTokens(ctx context.Context, refreshToken string) (*entity.TokenPair, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contentTimeout)
+	defer cancel()
+
+	current, err := s.findRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.IsRevoked() {
+		return nil, inerr.ErrorInvalidRefreshToken
+	}
+
+	if current.IsUsed() {
+		return nil, s.reuseDetected(ctx, current)
+	}
+
+	user, err := s.userRepo.FindOne(ctx, map[string]any{"id": current.UserID})
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	if err := CheckStatus(user); err != nil {
+		if err := s.revokeFamily(ctx, current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+
+	next := newRefreshToken(user.ID, current.FamilyID)
+	reused := false
+	err = s.refreshTokenRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.refreshTokenRepo.MarkUsed(ctx, current.ID, next.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// Another request rotated or revoked the token in the meantime
+			reused = true
+			return nil
+		}
+
+		return s.refreshTokenRepo.Create(ctx, next)
+	})
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	if reused {
+		return nil, s.reuseDetected(ctx, current)
+	}
+
+	return s.tokenPair(user.ID, next.ID)
+}
+
+func (s *service) Logout(ctx context.Context, refreshToken string, everywhere bool) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contentTimeout)
+	defer cancel()
+
+	token, err := s.findRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	// Stale tokens of a rotated or logged out session must not end the other sessions
+	if token.IsRevoked() {
+		return "", inerr.ErrorInvalidRefreshToken
+	}
+
+	if token.IsUsed() {
+		return "", s.reuseDetected(ctx, token)
+	}
+
+	if everywhere {
+		return token.UserID, s.revokeUser(ctx, token.UserID)
+	}
+
+	return token.UserID, s.revokeFamily(ctx, token.FamilyID)
+}
+
+func (s *service) LogoutEverywhere(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contentTimeout)
+	defer cancel()
+
+	return s.revokeUser(ctx, userID)
+}
+
+// findRefreshToken validates a refresh token and loads its record
+func (s *service) findRefreshToken(ctx context.Context, refreshToken string) (*entity.RefreshTokens, error) {
+	claims, err := security.ParseRefreshToken(refreshToken, s.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens issued before rotation was introduced have no jti and can't be tracked
+	if claims.TokenID == "" {
+		return nil, inerr.ErrorInvalidRefreshToken
+	}
+
+	token, err := s.refreshTokenRepo.FindOne(ctx, map[string]any{"id": claims.TokenID})
+	if err != nil {
+		if inerr.IsErrNotFound(err) {
+			return nil, inerr.ErrorInvalidRefreshToken
+		}
+		return nil, inerr.Err(err)
+	}
+
+	return token, nil
+}
+
+// reuseDetected revokes the family of a replayed token, it may have been stolen
+func (s *service) reuseDetected(ctx context.Context, token *entity.RefreshTokens) error {
+	logger.Warn(fmt.Sprintf("refresh token %s of user %s reused, revoking family %s", token.ID, token.UserID, token.FamilyID))
+
+	if err := s.revokeFamily(ctx, token.FamilyID); err != nil {
+		return err
+	}
+
+	return inerr.ErrorRefreshTokenReused
+}
+
+func (s *service) revokeFamily(ctx context.Context, familyID string) error {
+	err := s.refreshTokenRepo.UpdateDataWhere(ctx,
+		map[string]any{"revoked_at": time.Now()},
+		map[string]any{"family_id": familyID, "revoked_at": nil},
+	)
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// revokeUser revokes every refresh token of the user and the access tokens issued so far
+func (s *service) revokeUser(ctx context.Context, userID string) error {
+	now := time.Now()
+
+	err := s.refreshTokenRepo.UpdateDataWhere(ctx,
+		map[string]any{"revoked_at": now},
+		map[string]any{"user_id": userID, "revoked_at": nil},
+	)
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	err = s.userRepo.UpdateDataWhere(ctx,
+		map[string]any{"tokens_valid_after": now},
+		map[string]any{"id": userID},
+	)
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) tokenPair(userID, refreshTokenID string) (*entity.TokenPair, error) {
+	accessToken, refreshToken, err := security.GenerateTokenPair(userID, refreshTokenID, s.secret)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	return &entity.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func newRefreshToken(userID, familyID string) *entity.RefreshTokens {
+	now := time.Now()
+
+	return &entity.RefreshTokens{
+		ID:        uuid.New().String(),
+		FamilyID:  familyID,
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(security.RefreshTokenTTL),
+	}
+}
@@ -0,0 +1,30 @@
+package importer
+
+import (
+	"context"
+)
+
+type Service interface {
+	Import(ctx context.Context, opts Options) (*Stats, error)
+}
+
+type Options struct {
+	// GeonamesFiles are cities*.txt or allCountries.txt dumps
+	GeonamesFiles []string
+	// AlternateNamesFile is alternateNamesV2.txt, optional
+	AlternateNamesFile string
+	// Admin1CodesFile is admin1CodesASCII.txt used to resolve state names, optional
+	Admin1CodesFile string
+	// CountryInfoFile is countryInfo.txt used to resolve country names, optional
+	CountryInfoFile string
+	// FeatureClasses limits imported rows by GeoNames feature class, "P" (populated places) by default
+	FeatureClasses []string
+	BatchSize      int
+}
+
+type Stats struct {
+	Created        int
+	Updated        int
+	Skipped        int
+	AlternateNames int
+}
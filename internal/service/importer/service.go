@@ -0,0 +1,387 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/locations"
+	"github.com/AsaHero/whereismycity/pkg/geonames"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/shogo82148/pointer"
+)
+
+// nonLanguageCodes are isolanguage values of alternateNamesV2.txt that denote a kind of name rather than a language
+var nonLanguageCodes = map[string]bool{
+	"post": true, "iata": true, "icao": true, "faac": true, "abbr": true,
+	"link": true, "wkdt": true, "unlc": true, "tcid": true, "fr_1793": true,
+}
+
+var locationColumns = []string{"city", "state", "country", "code", "lat", "lng"}
+
+// region is a state or country whose alternate names are imported under the name locations store it by
+type region struct {
+	regionType  entity.RegionType
+	countryCode string
+	name        string
+}
+
+type service struct {
+	locationRepo locations.Repository
+}
+
+func New(locationRepo locations.Repository) Service {
+	return &service{
+		locationRepo: locationRepo,
+	}
+}
+
+func (s *service) Import(ctx context.Context, opts Options) (*Stats, error) {
+	if len(opts.GeonamesFiles) == 0 {
+		return nil, fmt.Errorf("at least one geonames file is required")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 1000
+	}
+	if len(opts.FeatureClasses) == 0 {
+		opts.FeatureClasses = []string{"P"}
+	}
+
+	admin1Codes, err := readOptional(opts.Admin1CodesFile, geonames.ReadAdmin1Codes)
+	if err != nil {
+		return nil, inerr.WithMessage(err, "failed to read admin1 codes")
+	}
+
+	countries, err := readOptional(opts.CountryInfoFile, geonames.ReadCountryInfo)
+	if err != nil {
+		return nil, inerr.WithMessage(err, "failed to read country info")
+	}
+
+	regions := regionsByGeonameID(admin1Codes, countries)
+
+	featureClasses := make(map[string]bool, len(opts.FeatureClasses))
+	for _, fc := range opts.FeatureClasses {
+		featureClasses[fc] = true
+	}
+
+	stats := &Stats{}
+
+	// === 1. Locations and their GeoNames IDs ===
+	for _, path := range opts.GeonamesFiles {
+		started := time.Now()
+		logger.Info(fmt.Sprintf("importing locations from %s", path))
+
+		batch := make([]geonames.Geoname, 0, opts.BatchSize)
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			if err := s.importLocations(ctx, batch, admin1Codes, countries, stats); err != nil {
+				return err
+			}
+			batch = batch[:0]
+
+			logger.Info(fmt.Sprintf("locations: %d created, %d updated, %d skipped", stats.Created, stats.Updated, stats.Skipped))
+			return nil
+		}
+
+		err := readFile(path, func(file *os.File) error {
+			return geonames.ReadGeonames(file, func(g geonames.Geoname) error {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				if !featureClasses[g.FeatureClass] {
+					stats.Skipped++
+					return nil
+				}
+
+				batch = append(batch, g)
+				if len(batch) >= opts.BatchSize {
+					return flush()
+				}
+				return nil
+			})
+		})
+		if err == nil {
+			err = flush()
+		}
+		if err != nil {
+			return stats, inerr.WithMessage(err, "failed to import %s", path)
+		}
+
+		logger.Info(fmt.Sprintf("imported %s in %s", path, time.Since(started).Round(time.Second)))
+	}
+
+	// === 2. Alternate names of the imported locations ===
+	if opts.AlternateNamesFile != "" {
+		started := time.Now()
+		logger.Info(fmt.Sprintf("importing alternate names from %s", opts.AlternateNamesFile))
+
+		batch := make([]geonames.AlternateName, 0, opts.BatchSize)
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			if err := s.importAlternateNames(ctx, batch, regions, stats); err != nil {
+				return err
+			}
+			batch = batch[:0]
+			return nil
+		}
+
+		read := 0
+		err := readFile(opts.AlternateNamesFile, func(file *os.File) error {
+			return geonames.ReadAlternateNames(file, func(a geonames.AlternateName) error {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				read++
+				if read%(opts.BatchSize*100) == 0 {
+					logger.Info(fmt.Sprintf("alternate names: %d read, %d imported", read, stats.AlternateNames))
+				}
+
+				if a.Name == "" {
+					return nil
+				}
+
+				batch = append(batch, a)
+				if len(batch) >= opts.BatchSize {
+					return flush()
+				}
+				return nil
+			})
+		})
+		if err == nil {
+			err = flush()
+		}
+		if err != nil {
+			return stats, inerr.WithMessage(err, "failed to import %s", opts.AlternateNamesFile)
+		}
+
+		logger.Info(fmt.Sprintf("imported %d alternate names in %s", stats.AlternateNames, time.Since(started).Round(time.Second)))
+	}
+
+	return stats, nil
+}
+
+// importLocations upserts a batch of geonames in a single transaction. Rows already linked to a location
+// through location_geoname_ids update that location, so re-running the import is idempotent.
+func (s *service) importLocations(ctx context.Context, batch []geonames.Geoname, admin1Codes, countries map[string]geonames.Region, stats *Stats) error {
+	return s.locationRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		geonameIDs := make([]int64, 0, len(batch))
+		for _, g := range batch {
+			geonameIDs = append(geonameIDs, g.GeonameID)
+		}
+
+		existing, err := s.locationRepo.FindIDsByGeonameIDs(ctx, geonameIDs)
+		if err != nil {
+			return err
+		}
+
+		var (
+			created   []*entity.Locations
+			createdBy []int64
+			updated   []*entity.Locations
+			seen      = make(map[int64]bool, len(batch))
+		)
+
+		for _, g := range batch {
+			// The same geoname may appear in several cities*.txt files
+			if seen[g.GeonameID] {
+				continue
+			}
+			seen[g.GeonameID] = true
+
+			location := &entity.Locations{
+				City:    g.Name,
+				State:   lookup(admin1Codes, g.CountryCode+"."+g.Admin1Code, ""),
+				Country: lookup(countries, g.CountryCode, g.CountryCode),
+				Code:    g.CountryCode,
+				Lat:     g.Lat,
+				Lng:     g.Lng,
+			}
+
+			if id, ok := existing[g.GeonameID]; ok {
+				location.ID = id
+				updated = append(updated, location)
+			} else {
+				created = append(created, location)
+				createdBy = append(createdBy, g.GeonameID)
+			}
+		}
+
+		if len(updated) > 0 {
+			if err := s.locationRepo.BatchUpsert(ctx, locationColumns, updated); err != nil {
+				return err
+			}
+		}
+
+		if len(created) > 0 {
+			if err := s.locationRepo.BatchCreate(ctx, created); err != nil {
+				return err
+			}
+
+			links := make([]*entity.LocationGeoNameIDs, 0, len(created))
+			for i, location := range created {
+				links = append(links, &entity.LocationGeoNameIDs{
+					LocationID: location.ID,
+					GeoNameID:  createdBy[i],
+				})
+			}
+
+			if err := s.locationRepo.LinkGeonameIDs(ctx, links); err != nil {
+				return err
+			}
+		}
+
+		stats.Created += len(created)
+		stats.Updated += len(updated)
+
+		return nil
+	})
+}
+
+// importAlternateNames upserts the alternate names of geonames that were imported as locations and of known
+// states and countries, skipping the rest
+func (s *service) importAlternateNames(ctx context.Context, batch []geonames.AlternateName, regions map[int64]region, stats *Stats) error {
+	return s.locationRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		geonameIDs := make([]int64, 0, len(batch))
+		for _, a := range batch {
+			geonameIDs = append(geonameIDs, a.GeonameID)
+		}
+
+		locationIDs, err := s.locationRepo.FindIDsByGeonameIDs(ctx, geonameIDs)
+		if err != nil {
+			return err
+		}
+
+		var regionNames []*entity.RegionAlternateNames
+		names := make([]*entity.LocationAlternateNames, 0, len(batch))
+		for _, a := range batch {
+			locationID, ok := locationIDs[a.GeonameID]
+			if !ok {
+				// Regions are only localized, so names that are not in a language are of no use
+				if r, ok := regions[a.GeonameID]; ok && a.ISOLanguage != "" && !nonLanguageCodes[a.ISOLanguage] {
+					regionNames = append(regionNames, &entity.RegionAlternateNames{
+						GeoNameID:       a.GeonameID,
+						AlternateNameID: a.AlternateNameID,
+						RegionType:      r.regionType,
+						CountryCode:     r.countryCode,
+						Name:            r.name,
+						ISOLanguageCode: a.ISOLanguage,
+						AlternateName:   a.Name,
+						IsPreferred:     a.IsPreferred,
+						IsShort:         a.IsShort,
+						IsColloquial:    a.IsColloquial,
+						IsHistoric:      a.IsHistoric,
+					})
+				}
+				continue
+			}
+
+			name := &entity.LocationAlternateNames{
+				LocationID:      locationID,
+				GeoNameID:       a.GeonameID,
+				AlternateNameID: a.AlternateNameID,
+				Type:            "name",
+				AlternateName:   a.Name,
+				IsPreferred:     a.IsPreferred,
+				IsShort:         a.IsShort,
+				IsColloquial:    a.IsColloquial,
+				IsHistoric:      a.IsHistoric,
+			}
+
+			switch {
+			case nonLanguageCodes[a.ISOLanguage]:
+				name.Type = a.ISOLanguage
+			case a.ISOLanguage != "":
+				name.ISOLanguageCode = pointer.String(a.ISOLanguage)
+			}
+
+			names = append(names, name)
+		}
+
+		if err := s.locationRepo.UpsertAlternateNames(ctx, names); err != nil {
+			return err
+		}
+
+		if err := s.locationRepo.UpsertRegionNames(ctx, regionNames); err != nil {
+			return err
+		}
+
+		stats.AlternateNames += len(names) + len(regionNames)
+
+		return nil
+	})
+}
+
+func readFile(path string, fn func(file *os.File) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return fn(file)
+}
+
+func readOptional(path string, read func(file io.Reader) (map[string]geonames.Region, error)) (map[string]geonames.Region, error) {
+	if path == "" {
+		return map[string]geonames.Region{}, nil
+	}
+
+	var result map[string]geonames.Region
+	err := readFile(path, func(file *os.File) error {
+		var err error
+		result, err = read(file)
+		return err
+	})
+
+	return result, err
+}
+
+func lookup(regions map[string]geonames.Region, key, fallback string) string {
+	if r, ok := regions[key]; ok {
+		return r.Name
+	}
+	return fallback
+}
+
+// regionsByGeonameID indexes states and countries by their GeoNames ID under the names importLocations stores
+func regionsByGeonameID(admin1Codes, countries map[string]geonames.Region) map[int64]region {
+	regions := make(map[int64]region, len(admin1Codes)+len(countries))
+
+	for code, r := range admin1Codes {
+		if r.GeonameID == 0 {
+			continue
+		}
+
+		countryCode, _, _ := strings.Cut(code, ".")
+		regions[r.GeonameID] = region{
+			regionType:  entity.RegionTypeState,
+			countryCode: countryCode,
+			name:        r.Name,
+		}
+	}
+
+	for code, r := range countries {
+		if r.GeonameID == 0 {
+			continue
+		}
+
+		regions[r.GeonameID] = region{
+			regionType:  entity.RegionTypeCountry,
+			countryCode: code,
+			name:        r.Name,
+		}
+	}
+
+	return regions
+}
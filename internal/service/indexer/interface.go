@@ -0,0 +1,26 @@
+package indexer
+
+import (
+	"context"
+)
+
+type Service interface {
+	Reindex(ctx context.Context, opts Options) (*Stats, error)
+}
+
+type Options struct {
+	// FromID resumes indexing after the given location id, overrides the state file
+	FromID int64
+	// StateFile keeps the last indexed location id so an interrupted run can be resumed, optional.
+	// It is removed once a run finishes, so the next one indexes every location again
+	StateFile string
+	// Recreate drops and recreates the collection, starting from the first location
+	Recreate  bool
+	BatchSize int
+}
+
+type Stats struct {
+	Indexed int
+	Failed  int
+	LastID  int64
+}
@@ -0,0 +1,209 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/embeddings"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/locations"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/typesense"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/AsaHero/whereismycity/pkg/utility"
+)
+
+type service struct {
+	locationRepo  locations.Repository
+	embeddingsAPI embeddings.Client
+	typesenseAPI  typesense.Client
+}
+
+func New(locationRepo locations.Repository, embeddingsAPI embeddings.Client, typesenseAPI typesense.Client) Service {
+	return &service{
+		locationRepo:  locationRepo,
+		embeddingsAPI: embeddingsAPI,
+		typesenseAPI:  typesenseAPI,
+	}
+}
+
+func (s *service) Reindex(ctx context.Context, opts Options) (*Stats, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 200
+	}
+
+	created, err := s.typesenseAPI.EnsureLocationsCollection(ctx, s.embeddingsAPI.Dimensions(), opts.Recreate)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+	if created {
+		logger.Info("created locations collection")
+	}
+
+	// === 1. Find out where to start ===
+	lastID := opts.FromID
+	if lastID == 0 && !opts.Recreate {
+		lastID, err = readState(opts.StateFile)
+		if err != nil {
+			return nil, inerr.WithMessage(err, "failed to read state file")
+		}
+	}
+	if lastID > 0 {
+		logger.Info(fmt.Sprintf("resuming after location %d", lastID))
+	}
+
+	stats := &Stats{LastID: lastID}
+	started := time.Now()
+
+	// === 2. Stream locations in id order, one batch at a time ===
+	for {
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		remaining, batch, err := s.locationRepo.FindAll(ctx, uint64(opts.BatchSize), 1, "id ASC",
+			map[string]any{"id > ?": stats.LastID}, "AlternateNames")
+		if err != nil {
+			return stats, inerr.Err(err)
+		}
+
+		if len(batch) == 0 {
+			break
+		}
+
+		if err := s.indexBatch(ctx, batch, stats); err != nil {
+			return stats, inerr.WithMessage(err, "failed to index locations after %d", stats.LastID)
+		}
+
+		stats.LastID = batch[len(batch)-1].ID
+		if err := writeState(opts.StateFile, stats.LastID); err != nil {
+			logger.Warn(fmt.Sprintf("failed to write state file: %v", err))
+		}
+
+		logger.Info(fmt.Sprintf("indexed %d locations, %d failed, %d left (last id %d, %s elapsed)",
+			stats.Indexed, stats.Failed, remaining-uint64(len(batch)), stats.LastID, time.Since(started).Round(time.Second)))
+	}
+
+	// A finished run starts over next time, picking up locations updated in place and documents that failed
+	if err := clearState(opts.StateFile); err != nil {
+		logger.Warn(fmt.Sprintf("failed to remove state file: %v", err))
+	}
+
+	return stats, nil
+}
+
+func (s *service) indexBatch(ctx context.Context, batch []*entity.Locations, stats *Stats) error {
+	texts := make([]string, len(batch))
+	for i, l := range batch {
+		texts[i] = embeddingText(l)
+	}
+
+	vectors, err := s.embeddingsAPI.GenerateBatch(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	documents := make([]typesense.LocationDocument, len(batch))
+	for i, l := range batch {
+		documents[i] = typesense.LocationDocument{
+			ID:           strconv.FormatInt(l.ID, 10),
+			LocationID:   l.ID,
+			City:         l.City,
+			Translations: translations(l),
+			State:        l.State,
+			Country:      l.Country,
+			Code:         l.Code,
+			Location:     []float64{l.Lat, l.Lng},
+			Embeddings:   vectors[i],
+		}
+	}
+
+	result, err := s.typesenseAPI.ImportLocations(ctx, documents)
+	if err != nil {
+		return err
+	}
+
+	for id, reason := range result.Failures {
+		logger.Warn(fmt.Sprintf("failed to index location %s: %s", id, reason))
+	}
+
+	stats.Indexed += result.Imported
+	stats.Failed += len(result.Failures)
+
+	return nil
+}
+
+// embeddingText builds the text a location is embedded from, normalized the same way search queries are
+func embeddingText(l *entity.Locations) string {
+	return utility.SynthesizeString(strings.Join([]string{l.City, l.State, l.Country}, " "))
+}
+
+// translations collects the distinct alternate names of a location, skipping
+// postal codes, links and other names that are not names of the place itself
+func translations(l *entity.Locations) []string {
+	seen := map[string]bool{strings.ToLower(l.City): true}
+	result := make([]string, 0, len(l.AlternateNames))
+
+	for _, name := range l.AlternateNames {
+		if name.Type != "" && name.Type != "name" {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimSpace(name.AlternateName))
+		if key == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		result = append(result, strings.TrimSpace(name.AlternateName))
+	}
+
+	return result
+}
+
+func readState(path string) (int64, error) {
+	if path == "" {
+		return 0, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// writeState replaces the state file atomically so an interrupted write never leaves it corrupted
+func writeState(path string, lastID int64) error {
+	if path == "" {
+		return nil
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatInt(lastID, 10)), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func clearState(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
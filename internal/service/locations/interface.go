@@ -0,0 +1,15 @@
+package locations
+
+import (
+	"context"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+type Service interface {
+	GetByID(ctx context.Context, id int64) (*entity.Locations, error)
+	GetByGeonameID(ctx context.Context, geonameID int64) (*entity.Locations, error)
+	// Localize sets the preferred city, state and country names in the first of the languages they are known in.
+	// Locations keep their canonical names only if the lookup fails
+	Localize(ctx context.Context, locations []*entity.Locations, languages []string)
+}
@@ -0,0 +1,142 @@
+package locations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/shogo82148/pointer"
+)
+
+// candidate is an alternate name of a city or a region compared by betterName
+type candidate struct {
+	name            string
+	language        string
+	isPreferred     bool
+	isShort         bool
+	alternateNameID int64
+}
+
+func (s *service) Localize(ctx context.Context, locations []*entity.Locations, languages []string) {
+	if len(locations) == 0 || len(languages) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	priority := make(map[string]int, len(languages))
+	for i, lang := range languages {
+		lang = strings.ToLower(lang)
+		if _, ok := priority[lang]; !ok {
+			priority[lang] = i
+		}
+	}
+
+	s.localizeCities(ctx, locations, languages, priority)
+	s.localizeRegions(ctx, locations, languages, priority)
+}
+
+func (s *service) localizeCities(ctx context.Context, locations []*entity.Locations, languages []string, priority map[string]int) {
+	ids := make([]int64, 0, len(locations))
+	for _, l := range locations {
+		ids = append(ids, l.ID)
+	}
+
+	names, err := s.locationRepo.FindNamesInLanguages(ctx, ids, languages)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("failed to localize locations: %v", err))
+		return
+	}
+
+	// Pick the best name of every location: requested language order first,
+	// then preferred names, then full names over short ones
+	best := make(map[int64]candidate, len(locations))
+	for _, name := range names {
+		c := candidate{
+			name:            name.AlternateName,
+			language:        strings.ToLower(pointer.StringValue(name.ISOLanguageCode)),
+			isPreferred:     name.IsPreferred,
+			isShort:         name.IsShort,
+			alternateNameID: name.AlternateNameID,
+		}
+
+		current, ok := best[name.LocationID]
+		if !ok || betterName(c, current, priority) {
+			best[name.LocationID] = c
+		}
+	}
+
+	for _, l := range locations {
+		if c, ok := best[l.ID]; ok {
+			l.LocalizedCity = pointer.String(c.name)
+			l.LocalizedLanguage = pointer.String(c.language)
+		}
+	}
+}
+
+// localizeRegions sets state and country names. Locations store regions by name, so names are matched
+// by region type, country code and the canonical name.
+func (s *service) localizeRegions(ctx context.Context, locations []*entity.Locations, languages []string, priority map[string]int) {
+	seen := make(map[string]bool)
+	codes := make([]string, 0, len(locations))
+	for _, l := range locations {
+		if l.Code != "" && !seen[l.Code] {
+			seen[l.Code] = true
+			codes = append(codes, l.Code)
+		}
+	}
+
+	names, err := s.locationRepo.FindRegionNamesInLanguages(ctx, codes, languages)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("failed to localize regions: %v", err))
+		return
+	}
+
+	best := make(map[string]candidate)
+	for _, name := range names {
+		c := candidate{
+			name:            name.AlternateName,
+			language:        strings.ToLower(name.ISOLanguageCode),
+			isPreferred:     name.IsPreferred,
+			isShort:         name.IsShort,
+			alternateNameID: name.AlternateNameID,
+		}
+
+		key := regionKey(name.RegionType, name.CountryCode, name.Name)
+		current, ok := best[key]
+		if !ok || betterName(c, current, priority) {
+			best[key] = c
+		}
+	}
+
+	for _, l := range locations {
+		if c, ok := best[regionKey(entity.RegionTypeState, l.Code, l.State)]; ok {
+			l.LocalizedState = pointer.String(c.name)
+		}
+		if c, ok := best[regionKey(entity.RegionTypeCountry, l.Code, l.Country)]; ok {
+			l.LocalizedCountry = pointer.String(c.name)
+		}
+	}
+}
+
+func regionKey(regionType entity.RegionType, countryCode, name string) string {
+	return string(regionType) + "\x00" + countryCode + "\x00" + name
+}
+
+func betterName(a, b candidate, priority map[string]int) bool {
+	pa := priority[a.language]
+	pb := priority[b.language]
+	if pa != pb {
+		return pa < pb
+	}
+	if a.isPreferred != b.isPreferred {
+		return a.isPreferred
+	}
+	if a.isShort != b.isShort {
+		return !a.isShort
+	}
+	return a.alternateNameID < b.alternateNameID
+}
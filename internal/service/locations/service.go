@@ -0,0 +1,72 @@
+package locations
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/locations"
+	"github.com/shogo82148/pointer"
+)
+
+var detailsPreloads = []string{"GeonameIDs", "AlternateNames"}
+
+type service struct {
+	contextTimeout time.Duration
+	locationRepo   locations.Repository
+}
+
+func New(contextTimeout time.Duration, locationRepo locations.Repository) Service {
+	return &service{
+		contextTimeout: contextTimeout,
+		locationRepo:   locationRepo,
+	}
+}
+
+func (s *service) GetByID(ctx context.Context, id int64) (*entity.Locations, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	location, err := s.locationRepo.FindOne(ctx, map[string]any{"id": id}, detailsPreloads...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.afterFind(location)
+
+	return location, nil
+}
+
+func (s *service) GetByGeonameID(ctx context.Context, geonameID int64) (*entity.Locations, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	location, err := s.locationRepo.FindByGeonameID(ctx, geonameID, detailsPreloads...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.afterFind(location)
+
+	return location, nil
+}
+
+// afterFind orders associations so responses are stable: preferred names first,
+// then by language and name; GeoNames IDs ascending
+func (service) afterFind(location *entity.Locations) {
+	sort.SliceStable(location.AlternateNames, func(i, j int) bool {
+		a, b := location.AlternateNames[i], location.AlternateNames[j]
+		if a.IsPreferred != b.IsPreferred {
+			return a.IsPreferred
+		}
+		if la, lb := pointer.StringValue(a.ISOLanguageCode), pointer.StringValue(b.ISOLanguageCode); la != lb {
+			return la < lb
+		}
+		return a.AlternateName < b.AlternateName
+	})
+
+	sort.Slice(location.GeonameIDs, func(i, j int) bool {
+		return location.GeonameIDs[i].GeoNameID < location.GeonameIDs[j].GeoNameID
+	})
+}
@@ -0,0 +1,12 @@
+package passwordreset
+
+import "context"
+
+type Service interface {
+	// Forgot mails a reset link when the email belongs to an active user.
+	// It succeeds for unknown emails too, so callers can't tell which accounts exist
+	Forgot(ctx context.Context, email string) error
+	// Reset sets a new password with a reset token, ends every session of the user and revokes their API keys,
+	// returning the user ID. Nothing changes unless all of it succeeds
+	Reset(ctx context.Context, token, password string) (string, error)
+}
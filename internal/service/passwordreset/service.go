@@ -0,0 +1,208 @@
+package passwordreset
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/passwordresets"
+	users_repo "github.com/AsaHero/whereismycity/internal/infrasturcture/repository/users"
+	"github.com/AsaHero/whereismycity/internal/service/apikeys"
+	"github.com/AsaHero/whereismycity/internal/service/auth"
+	"github.com/AsaHero/whereismycity/internal/service/users"
+	"github.com/AsaHero/whereismycity/pkg/mailer"
+	"github.com/AsaHero/whereismycity/pkg/security"
+	"github.com/google/uuid"
+)
+
+const mailBody = `Hello %s,
+
+We received a request to reset your password. Set a new one by opening the link below:
+
+%s
+
+The link expires in %s and can be used once. If you didn't ask for a reset, ignore this email.
+`
+
+type service struct {
+	contextTimeout time.Duration
+	tokenTTL       time.Duration
+	appURL         string
+	resetRepo      passwordresets.Repository
+	userRepo       users_repo.Repository
+	userService    users.Service
+	authService    auth.AuthService
+	apiKeyService  apikeys.Service
+	mailer         mailer.Mailer
+}
+
+func New(
+	contextTimeout time.Duration,
+	tokenTTL time.Duration,
+	appURL string,
+	resetRepo passwordresets.Repository,
+	userRepo users_repo.Repository,
+	userService users.Service,
+	authService auth.AuthService,
+	apiKeyService apikeys.Service,
+	mailer mailer.Mailer,
+) Service {
+	return &service{
+		contextTimeout: contextTimeout,
+		tokenTTL:       tokenTTL,
+		appURL:         appURL,
+		resetRepo:      resetRepo,
+		userRepo:       userRepo,
+		userService:    userService,
+		authService:    authService,
+		apiKeyService:  apiKeyService,
+		mailer:         mailer,
+	}
+}
+
+func (s *service) Forgot(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	user, err := s.userRepo.FindOne(ctx, map[string]any{"email": email})
+	if err != nil {
+		if inerr.IsErrNotFound(err) {
+			return nil
+		}
+		return inerr.Err(err)
+	}
+
+	if !user.IsActive() {
+		return nil
+	}
+
+	// Issuing the link in the background keeps the response time independent of whether the account exists
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.contextTimeout)
+		defer cancel()
+
+		if err := s.issue(ctx, user); err != nil {
+			inerr.Err(err)
+		}
+	}()
+
+	return nil
+}
+
+// issue stores a new reset for the user, expiring the older ones, and mails its link
+func (s *service) issue(ctx context.Context, user *entity.Users) error {
+	token, err := security.GenerateRandomToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reset := &entity.PasswordResets{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		TokenHash: security.HashToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.tokenTTL),
+	}
+
+	// Only the latest link works
+	if err := s.expireUnused(ctx, user.ID, now); err != nil {
+		return err
+	}
+
+	if err := s.resetRepo.Create(ctx, reset); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf(mailBody, user.Name, s.link(token), s.tokenTTL),
+	})
+}
+
+func (s *service) Reset(ctx context.Context, token, password string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	reset, err := s.resetRepo.FindOne(ctx, map[string]any{"token_hash": security.HashToken(token)})
+	if err != nil {
+		if inerr.IsErrNotFound(err) {
+			return "", inerr.ErrorInvalidResetToken
+		}
+		return "", inerr.Err(err)
+	}
+
+	now := time.Now()
+	if reset.IsUsed() || reset.IsExpired(now) {
+		return "", inerr.ErrorInvalidResetToken
+	}
+
+	// Hashing is slow, so it happens before the transaction holds any locks
+	passwordHash, err := security.HashPassword(password)
+	if err != nil {
+		return "", inerr.Err(err)
+	}
+
+	// The token is only burned together with the password change
+	err = s.resetRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.resetRepo.MarkUsed(ctx, reset.ID, now)
+		if err != nil {
+			return inerr.Err(err)
+		}
+		if !ok {
+			return inerr.ErrorInvalidResetToken
+		}
+
+		user, err := s.userService.GetByID(ctx, reset.UserID)
+		if err != nil {
+			return err
+		}
+
+		user.PasswordHash = passwordHash
+		if err := s.userService.Update(ctx, user); err != nil {
+			return err
+		}
+
+		if err := s.expireUnused(ctx, user.ID, now); err != nil {
+			return err
+		}
+
+		// Whoever knew the old password must not stay logged in nor keep the keys they created
+		if err := s.authService.LogoutEverywhere(ctx, user.ID); err != nil {
+			return err
+		}
+
+		return s.apiKeyService.RevokeAll(ctx, user.ID)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return reset.UserID, nil
+}
+
+func (s *service) expireUnused(ctx context.Context, userID string, now time.Time) error {
+	err := s.resetRepo.UpdateDataWhere(ctx,
+		map[string]any{"used_at": now},
+		map[string]any{"user_id": userID, "used_at": nil},
+	)
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+// link points to the reset page of the app, or is the bare token when no app URL is configured
+func (s *service) link(token string) string {
+	if s.appURL == "" {
+		return token
+	}
+
+	return strings.TrimRight(s.appURL, "/") + "/reset-password?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,16 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+type Service interface {
+	// HasPermission reports whether the role grants the permission, unknown roles grant nothing
+	HasPermission(ctx context.Context, role entity.UserRole, permission entity.Permission) (bool, error)
+	// List returns the permissions of every known role
+	List(ctx context.Context) (map[entity.UserRole][]entity.Permission, error)
+	// SetPermissions replaces the permissions of a role
+	SetPermissions(ctx context.Context, role entity.UserRole, permissions []entity.Permission) error
+}
@@ -0,0 +1,131 @@
+package rbac
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/rolepermissions"
+)
+
+type service struct {
+	contextTimeout      time.Duration
+	rolePermissionsRepo rolepermissions.Repository
+
+	// The mapping is checked on every request, so it is kept in memory for cacheTTL.
+	// Changes made through this instance apply at once, other instances pick them up after cacheTTL
+	cacheTTL time.Duration
+	mu       sync.RWMutex
+	grants   map[entity.UserRole]map[entity.Permission]struct{}
+	loadedAt time.Time
+}
+
+// New creates the RBAC service, a zero cacheTTL reads the mapping from the database on every check
+func New(contextTimeout, cacheTTL time.Duration, rolePermissionsRepo rolepermissions.Repository) Service {
+	return &service{
+		contextTimeout:      contextTimeout,
+		rolePermissionsRepo: rolePermissionsRepo,
+		cacheTTL:            cacheTTL,
+	}
+}
+
+func (s *service) HasPermission(ctx context.Context, role entity.UserRole, permission entity.Permission) (bool, error) {
+	grants, err := s.load(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := grants[role][permission]
+	return ok, nil
+}
+
+func (s *service) List(ctx context.Context) (map[entity.UserRole][]entity.Permission, error) {
+	grants, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[entity.UserRole][]entity.Permission, len(entity.UserRolesAll))
+	for _, role := range entity.UserRolesAll {
+		permissions := make([]entity.Permission, 0, len(grants[role]))
+		// Keep the declaration order so responses are stable
+		for _, permission := range entity.PermissionsAll {
+			if _, ok := grants[role][permission]; ok {
+				permissions = append(permissions, permission)
+			}
+		}
+		result[role] = permissions
+	}
+
+	return result, nil
+}
+
+func (s *service) SetPermissions(ctx context.Context, role entity.UserRole, permissions []entity.Permission) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if !role.IsValid() {
+		return inerr.ErrorUnknownRole
+	}
+
+	unique := make([]entity.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return inerr.ErrorUnknownPermission
+		}
+		if !slices.Contains(unique, permission) {
+			unique = append(unique, permission)
+		}
+	}
+
+	// Without it nobody could grant the permission back
+	if role == entity.UserRoleAdmin && !slices.Contains(unique, entity.PermissionRolesWrite) {
+		return inerr.ErrorRolesWriteRequired
+	}
+
+	if err := s.rolePermissionsRepo.Replace(ctx, role, unique); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// load returns the cached mapping, reading it again once it is older than cacheTTL
+func (s *service) load(ctx context.Context) (map[entity.UserRole]map[entity.Permission]struct{}, error) {
+	s.mu.RLock()
+	grants, loadedAt := s.grants, s.loadedAt
+	s.mu.RUnlock()
+
+	if grants != nil && time.Since(loadedAt) < s.cacheTTL {
+		return grants, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	_, rows, err := s.rolePermissionsRepo.FindAll(ctx, 0, 0, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	grants = make(map[entity.UserRole]map[entity.Permission]struct{})
+	for _, row := range rows {
+		if grants[row.Role] == nil {
+			grants[row.Role] = make(map[entity.Permission]struct{})
+		}
+		grants[row.Role][row.Permission] = struct{}{}
+	}
+
+	s.mu.Lock()
+	s.grants, s.loadedAt = grants, time.Now()
+	s.mu.Unlock()
+
+	return grants, nil
+}
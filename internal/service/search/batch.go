@@ -0,0 +1,258 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/locations"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/typesense"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/AsaHero/whereismycity/pkg/utility"
+)
+
+// transliterationWorkers limits concurrent calls to the transliterator during a batch search
+const transliterationWorkers = 8
+
+// batchItem holds the intermediate state of a single query during a batch search
+type batchItem struct {
+	query           string
+	transliteration string
+	embedding       []float64
+	result          typesense.HybridSearchResult
+	// rows holds the filtered locations of a query with filters, nil when the shared lookup applies
+	rows map[int64]*entity.Locations
+}
+
+func (s *service) BatchSearch(ctx context.Context, queries []entity.BatchSearchQuery) ([]*entity.BatchSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextDeadline)
+	defer cancel()
+
+	started := time.Now()
+	results := make([]*entity.BatchSearchResult, len(queries))
+	items := make([]*batchItem, len(queries))
+
+	// === 1. Validate and preprocess ===
+	for i, q := range queries {
+		results[i] = &entity.BatchSearchResult{
+			ID:    q.ID,
+			Query: q.Query,
+			Limit: q.Limit,
+		}
+
+		if results[i].Limit == 0 {
+			results[i].Limit = 20
+		}
+
+		query := utility.SynthesizeString(q.Query)
+		if query == "" {
+			results[i].Err = inerr.ErrorEmptySearhQuery
+			continue
+		}
+
+		results[i].SearchResult.Query = query
+		items[i] = &batchItem{query: query}
+	}
+
+	// === 2. Transliterate concurrently ===
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, transliterationWorkers)
+	for i := range items {
+		if items[i] == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ctx, cancel := s.dependencyContext(ctx)
+			defer cancel()
+
+			transliteration, err := s.transliteratorAPI.Transliterate(ctx, items[i].query)
+			if err != nil {
+				logger.Warn(fmt.Sprintf("batch search degraded, transliteration failed: %v", err))
+				results[i].Degrade(entity.DegradedReasonTransliteration)
+				return
+			}
+			items[i].transliteration = transliteration
+			results[i].Transliteration = transliteration
+		}(i)
+	}
+	wg.Wait()
+
+	// === 3. Generate embeddings in a single request (deduplicated) ===
+	textIndex := make(map[string]int)
+	var texts []string
+	for i := range items {
+		if items[i] == nil || results[i].Err != nil {
+			continue
+		}
+		if _, ok := textIndex[items[i].query]; !ok {
+			textIndex[items[i].query] = len(texts)
+			texts = append(texts, items[i].query)
+		}
+	}
+
+	if len(texts) == 0 {
+		return results, nil
+	}
+
+	// Without embeddings every query falls back to text-only search
+	embeddingsCtx, cancelEmbeddings := s.dependencyContext(ctx)
+	embeddings, err := s.embeddingsAPI.GenerateBatch(embeddingsCtx, texts)
+	cancelEmbeddings()
+	if err != nil {
+		logger.Warn(fmt.Sprintf("batch search degraded, embeddings failed: %v", err))
+		embeddings = nil
+		for i := range items {
+			if items[i] != nil && results[i].Err == nil {
+				results[i].Degrade(entity.DegradedReasonEmbeddings)
+			}
+		}
+	}
+
+	// === 4. Pack all queries into one multi search ===
+	var (
+		groups     [][]typesense.MultiHybridSearchRequest
+		groupItems []int
+	)
+	for i := range items {
+		if items[i] == nil || results[i].Err != nil {
+			continue
+		}
+
+		if embeddings != nil {
+			items[i].embedding = embeddings[textIndex[items[i].query]]
+		}
+
+		groups = append(groups, searchRequests(items[i].query, items[i].transliteration, items[i].embedding, queries[i].Filter))
+		groupItems = append(groupItems, i)
+	}
+
+	searchResults, err := s.typesenseAPI.BatchHybridSearchLocations(ctx, groups)
+	if err != nil {
+		return nil, inerr.Err(err)
+	}
+
+	idSet := make(map[int64]struct{})
+	for g, i := range groupItems {
+		items[i].result = searchResults[g]
+		if searchResults[g].Err != nil {
+			results[i].Err = inerr.Err(searchResults[g].Err)
+			continue
+		}
+		for _, id := range searchResults[g].IDs {
+			idSet[id] = struct{}{}
+		}
+	}
+
+	if len(idSet) == 0 {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Locations = []*entity.Locations{}
+			}
+		}
+		s.recordBatch(results, queries, time.Since(started))
+		return results, nil
+	}
+
+	// === 5. Fetch matched location entities from DB ===
+	// Unfiltered queries share a single lookup. Filtered queries get the same database filter
+	// as a single search, so rows that changed since they were indexed drop out the same way
+	sharedIDs := make(map[int64]struct{}, len(idSet))
+	for _, i := range groupItems {
+		if results[i].Err != nil {
+			continue
+		}
+
+		if len(items[i].result.IDs) == 0 {
+			items[i].rows = map[int64]*entity.Locations{}
+			continue
+		}
+
+		conditions := locations.FilterConditions(queries[i].Filter)
+		if len(conditions) == 0 {
+			for _, id := range items[i].result.IDs {
+				sharedIDs[id] = struct{}{}
+			}
+			continue
+		}
+
+		conditions["id"] = items[i].result.IDs
+		_, rows, err := s.locationRepo.FindAll(ctx, 0, 1, "", conditions)
+		if err != nil {
+			return nil, inerr.Err(err)
+		}
+		items[i].rows = indexByID(rows)
+	}
+
+	var sharedRows map[int64]*entity.Locations
+	if len(sharedIDs) > 0 {
+		ids := make([]int64, 0, len(sharedIDs))
+		for id := range sharedIDs {
+			ids = append(ids, id)
+		}
+
+		_, rows, err := s.locationRepo.FindAll(ctx, 0, 1, "", map[string]any{"id": ids})
+		if err != nil {
+			return nil, inerr.Err(err)
+		}
+		sharedRows = indexByID(rows)
+	}
+
+	// === 6. Score, sort and truncate each query's locations ===
+	for _, i := range groupItems {
+		if results[i].Err != nil {
+			continue
+		}
+
+		rows := items[i].rows
+		if rows == nil {
+			rows = sharedRows
+		}
+
+		matched := make([]*entity.Locations, 0, len(items[i].result.IDs))
+		for _, id := range items[i].result.IDs {
+			if l, ok := rows[id]; ok {
+				// Copy so that scores of one query don't leak into another
+				location := *l
+				matched = append(matched, &location)
+			}
+		}
+
+		s.scoreAndSort(matched, items[i].result.Documents, queries[i].Ranking)
+
+		if uint(len(matched)) > results[i].Limit {
+			matched = matched[:results[i].Limit]
+		}
+
+		results[i].Locations = matched
+	}
+
+	s.recordBatch(results, queries, time.Since(started))
+
+	return results, nil
+}
+
+// recordBatch logs every successful query of a batch with the latency of the whole batch
+func (s *service) recordBatch(results []*entity.BatchSearchResult, queries []entity.BatchSearchQuery, latency time.Duration) {
+	for i := range results {
+		if results[i].Err == nil {
+			s.record(&results[i].SearchResult, queries[i].SearchOptions, latency)
+		}
+	}
+}
+
+func indexByID(locations []*entity.Locations) map[int64]*entity.Locations {
+	byID := make(map[int64]*entity.Locations, len(locations))
+	for _, l := range locations {
+		byID[l.ID] = l
+	}
+	return byID
+}
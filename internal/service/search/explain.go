@@ -0,0 +1,61 @@
+package search
+
+import (
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/typesense"
+)
+
+// explain builds the explanation of a search from the typesense trace and the ranked candidates
+func explain(query, transliteration string, strategy entity.RankingStrategy, trace *typesense.SearchTrace, ranked []*entity.Locations, limit uint) *entity.SearchExplanation {
+	explanation := &entity.SearchExplanation{
+		NormalizedQuery: query,
+		Transliteration: transliteration,
+		Strategy:        strategy,
+		Subqueries:      []entity.SubqueryExplanation{},
+		Merges:          []entity.MergeExplanation{},
+		Scores:          make([]entity.ScoreExplanation, 0, len(ranked)),
+	}
+
+	if trace != nil {
+		for _, subquery := range trace.Subqueries {
+			sub := entity.SubqueryExplanation{
+				Query: subquery.Query,
+				Error: subquery.Error,
+				Hits:  make([]entity.HitExplanation, 0, len(subquery.Hits)),
+			}
+			for i, hit := range subquery.Hits {
+				sub.Hits = append(sub.Hits, entity.HitExplanation{
+					LocationID:      hit.ID,
+					City:            hit.City,
+					Position:        i + 1,
+					VectorDistance:  hit.VectorDistance,
+					TextMatchScore:  hit.TextMatchScore,
+					RankFusionScore: hit.RankFusionScore,
+				})
+			}
+			explanation.Subqueries = append(explanation.Subqueries, sub)
+		}
+
+		for _, merge := range trace.Merges {
+			explanation.Merges = append(explanation.Merges, entity.MergeExplanation{
+				LocationID: merge.LocationID,
+				Subquery:   merge.Subquery,
+				Position:   merge.Position,
+				Action:     merge.Action,
+				Updated:    merge.Updated,
+			})
+		}
+	}
+
+	for i, l := range ranked {
+		explanation.Scores = append(explanation.Scores, entity.ScoreExplanation{
+			LocationID: l.ID,
+			City:       l.City,
+			Position:   i + 1,
+			Score:      l.Score,
+			Returned:   uint(i) < limit,
+		})
+	}
+
+	return explanation
+}
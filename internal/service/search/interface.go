@@ -7,5 +7,13 @@ import (
 )
 
 type Service interface {
-	Search(ctx context.Context, query string, limit uint, filter entity.LocationFilterOptions) ([]*entity.Locations, error)
+	Search(ctx context.Context, query string, limit uint, opts entity.SearchOptions) (*entity.SearchResult, error)
+	BatchSearch(ctx context.Context, queries []entity.BatchSearchQuery) ([]*entity.BatchSearchResult, error)
+	Autocomplete(ctx context.Context, query string, limit uint, filter entity.LocationFilterOptions) ([]*entity.Locations, error)
+	ReverseGeocode(ctx context.Context, lat, lng float64, limit uint) ([]*entity.Locations, error)
+}
+
+// Recorder saves finished searches, it must not block
+type Recorder interface {
+	Record(log *entity.SearchLogs)
 }
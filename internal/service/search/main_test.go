@@ -0,0 +1,23 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AsaHero/whereismycity/pkg/config"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "search-test")
+	if err != nil {
+		panic(err)
+	}
+
+	logger.Init(&config.Config{LogLevel: "panic"}, filepath.Join(dir, "search.log"))
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
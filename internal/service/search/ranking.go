@@ -0,0 +1,173 @@
+package search
+
+import (
+	"math"
+	"sort"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/typesense"
+	"github.com/shogo82148/pointer"
+)
+
+// RankingConfig holds the default strategy and the weights of every strategy
+type RankingConfig struct {
+	Strategy entity.RankingStrategy
+	// VectorWeight and TextWeight blend the scores of the weighted and minmax strategies
+	VectorWeight float64
+	TextWeight   float64
+	// RRFK dampens the advantage of top positions in reciprocal rank fusion
+	RRFK float64
+	// RRFWeights weigh the result list of every subquery: original query first, then transliterated.
+	// Missing weights default to 1
+	RRFWeights []float64
+}
+
+// DefaultRankingConfig matches the hard-coded ranking used before strategies were configurable
+func DefaultRankingConfig() RankingConfig {
+	return RankingConfig{
+		Strategy:     entity.RankingWeighted,
+		VectorWeight: 0.3,
+		TextWeight:   0.7,
+		RRFK:         60,
+		RRFWeights:   []float64{1, 1},
+	}
+}
+
+// scoreAndSort injects vector/text scores from typesense documents, scores locations
+// with the given strategy and sorts them by score in descending order.
+// The sort is stable, so locations with equal scores keep their incoming order.
+// Returns the strategy that was actually applied
+func (s *service) scoreAndSort(locations []*entity.Locations, documentsMap map[int64]typesense.Locations, strategy entity.RankingStrategy) entity.RankingStrategy {
+	if strategy == "" {
+		strategy = s.ranking.Strategy
+	}
+
+	for i := range locations {
+		doc := documentsMap[locations[i].ID]
+		locations[i].VectorDistance = doc.VectorDistance
+		locations[i].TextMatchScore = doc.TextMatchScore
+		locations[i].RankFusionScore = doc.RankFusionScore
+	}
+
+	switch strategy {
+	case entity.RankingTypesense:
+		if !s.typesenseScores(locations) {
+			strategy = entity.RankingWeighted
+		}
+	case entity.RankingRRF:
+		s.rrfScores(locations, documentsMap)
+	case entity.RankingMinMax:
+		s.minMaxScores(locations)
+	default:
+		strategy = entity.RankingWeighted
+		s.weightedScores(locations)
+	}
+
+	sort.SliceStable(locations, func(i, j int) bool {
+		if locations[i].Score == nil {
+			return false
+		}
+
+		if locations[j].Score == nil {
+			return true
+		}
+
+		return *locations[i].Score > *locations[j].Score
+	})
+
+	return strategy
+}
+
+func (s *service) weightedScores(locations []*entity.Locations) {
+	for _, l := range locations {
+		l.Score = pointer.Float64(s.ranking.VectorWeight*vectorSimilarity(l) + s.ranking.TextWeight*logTextScore(l))
+	}
+}
+
+// typesenseScores keeps typesense's own rank fusion score. Text-only searches carry no
+// fusion score, in which case the weighted strategy is used instead and false is returned
+func (s *service) typesenseScores(locations []*entity.Locations) bool {
+	for _, l := range locations {
+		if l.RankFusionScore == nil {
+			s.weightedScores(locations)
+			return false
+		}
+	}
+
+	for _, l := range locations {
+		l.Score = pointer.Float64(*l.RankFusionScore)
+	}
+	return true
+}
+
+func (s *service) rrfScores(locations []*entity.Locations, documentsMap map[int64]typesense.Locations) {
+	for _, l := range locations {
+		var score float64
+		for set, rank := range documentsMap[l.ID].Ranks {
+			weight := 1.0
+			if set < len(s.ranking.RRFWeights) {
+				weight = s.ranking.RRFWeights[set]
+			}
+			score += weight / (s.ranking.RRFK + float64(rank))
+		}
+		l.Score = pointer.Float64(score)
+	}
+}
+
+func (s *service) minMaxScores(locations []*entity.Locations) {
+	vectorScores := make([]float64, len(locations))
+	textScores := make([]float64, len(locations))
+	for i, l := range locations {
+		vectorScores[i] = vectorSimilarity(l)
+		if l.TextMatchScore != nil {
+			textScores[i] = float64(*l.TextMatchScore)
+		}
+	}
+
+	minMaxNormalize(vectorScores)
+	minMaxNormalize(textScores)
+
+	for i, l := range locations {
+		l.Score = pointer.Float64(s.ranking.VectorWeight*vectorScores[i] + s.ranking.TextWeight*textScores[i])
+	}
+}
+
+// vectorSimilarity turns the cosine distance (lower is better) into a similarity
+func vectorSimilarity(l *entity.Locations) float64 {
+	if l.VectorDistance == nil {
+		return 0
+	}
+	return float64(1.0 - *l.VectorDistance)
+}
+
+// logTextScore normalizes text_match_score using logarithmic scale,
+// assuming the score maxes out around 1e20
+func logTextScore(l *entity.Locations) float64 {
+	if l.TextMatchScore == nil || *l.TextMatchScore <= 0 {
+		return 0
+	}
+	return math.Min(math.Log10(float64(*l.TextMatchScore))/20.0, 1.0)
+}
+
+// minMaxNormalize scales values into [0, 1] in place; equal values all become 1
+func minMaxNormalize(values []float64) {
+	if len(values) == 0 {
+		return
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	for i, v := range values {
+		if hi == lo {
+			if hi != 0 {
+				values[i] = 1
+			}
+			continue
+		}
+		values[i] = (v - lo) / (hi - lo)
+	}
+}
@@ -0,0 +1,176 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/typesense"
+	"github.com/shogo82148/pointer"
+)
+
+func locationIDs(locations []*entity.Locations) []int64 {
+	ids := make([]int64, len(locations))
+	for i, l := range locations {
+		ids[i] = l.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func newLocations(ids ...int64) []*entity.Locations {
+	locations := make([]*entity.Locations, len(ids))
+	for i, id := range ids {
+		locations[i] = &entity.Locations{ID: id}
+	}
+	return locations
+}
+
+func TestOrderByIDs(t *testing.T) {
+	tests := []struct {
+		name      string
+		locations []int64
+		ids       []int64
+		want      []int64
+	}{
+		{
+			name:      "restores the order of ids",
+			locations: []int64{3, 1, 2},
+			ids:       []int64{1, 2, 3},
+			want:      []int64{1, 2, 3},
+		},
+		{
+			name:      "skips ids that were not found",
+			locations: []int64{2, 4},
+			ids:       []int64{1, 2, 3, 4},
+			want:      []int64{2, 4},
+		},
+		{
+			name:      "drops locations missing from ids",
+			locations: []int64{1, 2, 5},
+			ids:       []int64{2, 1},
+			want:      []int64{2, 1},
+		},
+		{
+			name:      "empty",
+			locations: nil,
+			ids:       []int64{1},
+			want:      []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := locationIDs(orderByIDs(newLocations(tt.locations...), tt.ids))
+			if !equalIDs(got, tt.want) {
+				t.Errorf("orderByIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreAndSort(t *testing.T) {
+	tests := []struct {
+		name         string
+		strategy     entity.RankingStrategy
+		locations    []int64
+		documents    map[int64]typesense.Locations
+		want         []int64
+		wantStrategy entity.RankingStrategy
+	}{
+		{
+			name:      "equal scores keep the incoming order",
+			locations: []int64{3, 1, 2},
+			documents: map[int64]typesense.Locations{
+				1: {TextMatchScore: pointer.Int64(1000)},
+				2: {TextMatchScore: pointer.Int64(1000)},
+				3: {TextMatchScore: pointer.Int64(1000)},
+			},
+			want:         []int64{3, 1, 2},
+			wantStrategy: entity.RankingWeighted,
+		},
+		{
+			name:      "higher scores come first, ties stay in place",
+			locations: []int64{1, 2, 3, 4},
+			documents: map[int64]typesense.Locations{
+				1: {TextMatchScore: pointer.Int64(10)},
+				2: {TextMatchScore: pointer.Int64(1000)},
+				3: {TextMatchScore: pointer.Int64(10)},
+				4: {TextMatchScore: pointer.Int64(1000)},
+			},
+			want:         []int64{2, 4, 1, 3},
+			wantStrategy: entity.RankingWeighted,
+		},
+		{
+			name:      "vector similarity breaks equal text scores",
+			locations: []int64{1, 2},
+			documents: map[int64]typesense.Locations{
+				1: {TextMatchScore: pointer.Int64(1000), VectorDistance: pointer.Float32(0.5)},
+				2: {TextMatchScore: pointer.Int64(1000), VectorDistance: pointer.Float32(0.1)},
+			},
+			want:         []int64{2, 1},
+			wantStrategy: entity.RankingWeighted,
+		},
+		{
+			name:      "typesense without fusion scores falls back to weighted",
+			strategy:  entity.RankingTypesense,
+			locations: []int64{1, 2},
+			documents: map[int64]typesense.Locations{
+				1: {TextMatchScore: pointer.Int64(10)},
+				2: {TextMatchScore: pointer.Int64(1000)},
+			},
+			want:         []int64{2, 1},
+			wantStrategy: entity.RankingWeighted,
+		},
+		{
+			name:      "rrf ties keep the incoming order",
+			strategy:  entity.RankingRRF,
+			locations: []int64{2, 1},
+			documents: map[int64]typesense.Locations{
+				1: {Ranks: map[int]int{0: 1}},
+				2: {Ranks: map[int]int{1: 1}},
+			},
+			want:         []int64{2, 1},
+			wantStrategy: entity.RankingRRF,
+		},
+		{
+			name:      "minmax with equal inputs keeps the incoming order",
+			strategy:  entity.RankingMinMax,
+			locations: []int64{3, 2, 1},
+			documents: map[int64]typesense.Locations{
+				1: {TextMatchScore: pointer.Int64(5)},
+				2: {TextMatchScore: pointer.Int64(5)},
+				3: {TextMatchScore: pointer.Int64(5)},
+			},
+			want:         []int64{3, 2, 1},
+			wantStrategy: entity.RankingMinMax,
+		},
+	}
+
+	s := &service{ranking: DefaultRankingConfig()}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locations := newLocations(tt.locations...)
+
+			strategy := s.scoreAndSort(locations, tt.documents, tt.strategy)
+			if strategy != tt.wantStrategy {
+				t.Errorf("scoreAndSort() strategy = %q, want %q", strategy, tt.wantStrategy)
+			}
+
+			if got := locationIDs(locations); !equalIDs(got, tt.want) {
+				t.Errorf("scoreAndSort() order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
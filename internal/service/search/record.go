@@ -0,0 +1,67 @@
+package search
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/shogo82148/pointer"
+)
+
+// record hands a finished search over to the recorder, if any
+func (s *service) record(result *entity.SearchResult, opts entity.SearchOptions, latency time.Duration) {
+	if s.recorder == nil {
+		return
+	}
+
+	log := &entity.SearchLogs{
+		UserID:          opts.UserID,
+		Query:           result.Query,
+		Transliteration: result.Transliteration,
+		Filters:         filtersJSON(opts.Filter),
+		ResultCount:     len(result.Locations),
+		LatencyMs:       latency.Milliseconds(),
+		Degraded:        result.Degraded,
+	}
+
+	if len(result.Locations) > 0 {
+		log.TopLocationID = pointer.Int64(result.Locations[0].ID)
+	}
+
+	s.recorder.Record(log)
+}
+
+// filtersJSON encodes the applied location filters, nil when there are none
+func filtersJSON(filter entity.LocationFilterOptions) *string {
+	filters := make(map[string]any)
+
+	if filter.Country != nil {
+		filters["country"] = *filter.Country
+	}
+	if filter.State != nil {
+		filters["state"] = *filter.State
+	}
+	if filter.Code != nil {
+		filters["code"] = *filter.Code
+	}
+	if bbox := filter.BBox; bbox != nil {
+		filters["bbox"] = []float64{bbox.MinLng, bbox.MinLat, bbox.MaxLng, bbox.MaxLat}
+	}
+	if near := filter.Near; near != nil {
+		filters["near"] = []float64{near.Lat, near.Lng}
+	}
+	if filter.RadiusKm != nil {
+		filters["radius_km"] = *filter.RadiusKm
+	}
+
+	if len(filters) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return nil
+	}
+
+	return pointer.String(string(data))
+}
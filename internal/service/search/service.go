@@ -3,8 +3,8 @@ package search
 import (
 	"context"
 	"fmt"
-	"math"
-	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/AsaHero/whereismycity/internal/entity"
@@ -13,32 +13,44 @@ import (
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/locations"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/transliterator"
 	"github.com/AsaHero/whereismycity/internal/infrasturcture/typesense"
+	"github.com/AsaHero/whereismycity/pkg/logger"
 	"github.com/AsaHero/whereismycity/pkg/utility"
 	"github.com/shogo82148/pointer"
+	"github.com/sirupsen/logrus"
 )
 
 type service struct {
 	contextDeadline   time.Duration
+	dependencyTimeout time.Duration
+	ranking           RankingConfig
 	locationRepo      locations.Repository
 	embeddingsAPI     embeddings.Client
 	typesenseAPI      typesense.Client
 	transliteratorAPI transliterator.Client
+	recorder          Recorder
 }
 
-func New(contextDeadline time.Duration, locationRepo locations.Repository, embeddingsAPI embeddings.Client, typesenseAPI typesense.Client, transliteratorAPI transliterator.Client) Service {
+// New creates the search service. dependencyTimeout bounds every transliteration and embeddings call,
+// zero leaves them bounded by the request deadline only
+func New(contextDeadline, dependencyTimeout time.Duration, ranking RankingConfig, locationRepo locations.Repository, embeddingsAPI embeddings.Client, typesenseAPI typesense.Client, transliteratorAPI transliterator.Client, recorder Recorder) Service {
 	return &service{
 		contextDeadline:   contextDeadline,
+		dependencyTimeout: dependencyTimeout,
+		ranking:           ranking,
 		locationRepo:      locationRepo,
 		embeddingsAPI:     embeddingsAPI,
 		typesenseAPI:      typesenseAPI,
 		transliteratorAPI: transliteratorAPI,
+		recorder:          recorder,
 	}
 }
 
-func (s *service) Search(ctx context.Context, query string, limit uint, filter entity.LocationFilterOptions) ([]*entity.Locations, error) {
+func (s *service) Search(ctx context.Context, query string, limit uint, opts entity.SearchOptions) (*entity.SearchResult, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.contextDeadline)
 	defer cancel()
 
+	started := time.Now()
+
 	// === 1. Validate and preprocess ===
 	if query == "" {
 		return nil, inerr.ErrorEmptySearhQuery
@@ -48,98 +60,222 @@ func (s *service) Search(ctx context.Context, query string, limit uint, filter e
 	}
 	query = utility.SynthesizeString(query) // normalize accents, punctuation, etc.
 
-	// === 2. Transliterate ===
-	transliteratedQuery, err := s.transliteratorAPI.Transliterate(ctx, query)
+	result := &entity.SearchResult{Query: query}
+
+	// === 2-3. Transliterate and generate embeddings concurrently, each within the dependency budget ===
+	var (
+		wg                  sync.WaitGroup
+		transliteratedQuery string
+		embedding           []float64
+		transliterationErr  error
+		embeddingsErr       error
+		err                 error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		ctx, cancel := s.dependencyContext(ctx)
+		defer cancel()
+		transliteratedQuery, transliterationErr = s.transliteratorAPI.Transliterate(ctx, query)
+	}()
+	go func() {
+		defer wg.Done()
+		ctx, cancel := s.dependencyContext(ctx)
+		defer cancel()
+		embedding, embeddingsErr = s.embeddingsAPI.Generate(ctx, query)
+	}()
+	wg.Wait()
+
+	// Without transliteration only the original query is searched
+	if transliterationErr != nil {
+		logger.Warn(fmt.Sprintf("search degraded, transliteration failed: %v", transliterationErr))
+		result.Degrade(entity.DegradedReasonTransliteration)
+		transliteratedQuery = ""
+	}
+	result.Transliteration = transliteratedQuery
+
+	// Without embeddings typesense falls back to text-only search
+	if embeddingsErr != nil {
+		logger.Warn(fmt.Sprintf("search degraded, embeddings failed: %v", embeddingsErr))
+		result.Degrade(entity.DegradedReasonEmbeddings)
+		embedding = nil
+	}
+
+	// === 4. Perform hybrid multi-search with original and transliterated ===
+	var (
+		requests     = searchRequests(query, transliteratedQuery, embedding, opts.Filter)
+		locationIDs  []int64
+		documentsMap map[int64]typesense.Locations
+		trace        *typesense.SearchTrace
+	)
+	if opts.Explain {
+		locationIDs, documentsMap, trace, err = s.typesenseAPI.ExplainHybridSearchLocations(ctx, requests)
+	} else {
+		locationIDs, documentsMap, err = s.typesenseAPI.MultiHybridSearchLocations(ctx, requests)
+	}
 	if err != nil {
 		return nil, inerr.Err(err)
 	}
 
-	// === 3. Generate embeddings ===
-	embedding, err := s.embeddingsAPI.Generate(ctx, query)
+	// === 5. Fetch every matched location entity from DB ===
+	// No limit here: candidates have to be ranked before they are cut to the requested limit
+	conditions := locations.FilterConditions(opts.Filter)
+	conditions["id"] = locationIDs
+
+	_, locations, err := s.locationRepo.FindAll(ctx, 0, 1, "", conditions)
 	if err != nil {
 		return nil, inerr.Err(err)
 	}
 
-	// === 4. Perform hybrid multi-search with original and transliterated ===
-	locationIDs, documentsMap, err := s.typesenseAPI.MultiHybridSearchLocations(ctx, []typesense.MultiHybridSearchRequest{
+	// === 6. Inject Typesense scores, rank, sort and truncate ===
+	// Restoring typesense order first makes it the tie-breaker of the stable sort
+	locations = orderByIDs(locations, locationIDs)
+	strategy := s.scoreAndSort(locations, documentsMap, opts.Ranking)
+
+	if opts.Explain {
+		result.Explanation = explain(query, transliteratedQuery, strategy, trace, locations, limit)
+	}
+
+	if uint(len(locations)) > limit {
+		locations = locations[:limit]
+	}
+
+	for i, v := range locations {
+		logger.Debug("search result", logrus.Fields{
+			"query":           query,
+			"transliteration": transliteratedQuery,
+			"strategy":        strategy,
+			"position":        i + 1,
+			"location_id":     v.ID,
+			"city":            v.City,
+			"score":           pointer.Float64Value(v.Score),
+		})
+	}
+
+	result.Locations = locations
+
+	s.record(result, opts, time.Since(started))
+
+	return result, nil
+}
+
+// dependencyContext bounds a call to a dependency search can do without
+func (s *service) dependencyContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.dependencyTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.dependencyTimeout)
+}
+
+// searchRequests builds typesense searches for the original and, when available, the transliterated query.
+// Without an embedding typesense falls back to text-only search.
+func searchRequests(query, transliteratedQuery string, embedding []float64, filter entity.LocationFilterOptions) []typesense.MultiHybridSearchRequest {
+	requests := []typesense.MultiHybridSearchRequest{
 		{
 			Query:      query,
 			Embeddings: embedding,
 			Limit:      50,
+			Filter:     filter,
 		},
-		{
+	}
+
+	if transliteratedQuery != "" {
+		requests = append(requests, typesense.MultiHybridSearchRequest{
 			Query:      transliteratedQuery,
 			Embeddings: embedding,
 			Limit:      50,
-		},
-	})
-	if err != nil {
-		return nil, inerr.Err(err)
+			Filter:     filter,
+		})
 	}
 
-	// === 5. Fetch matched location entities from DB ===
-	_, locations, err := s.locationRepo.FindAll(ctx, uint64(limit), 1, "", map[string]any{"id": locationIDs})
+	return requests
+}
+
+// Autocomplete returns prefix matches straight from typesense, skipping transliteration,
+// embeddings and database hydration to keep latency low
+func (s *service) Autocomplete(ctx context.Context, query string, limit uint, filter entity.LocationFilterOptions) ([]*entity.Locations, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, inerr.ErrorEmptySearhQuery
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	documents, err := s.typesenseAPI.AutocompleteLocations(ctx, typesense.AutocompleteRequest{
+		Query:  query,
+		Limit:  int(limit),
+		Filter: filter,
+	})
 	if err != nil {
 		return nil, inerr.Err(err)
 	}
 
-	// === 6. Inject vector/text/rank scores from Typesense into entity ===
-	for i := range locations {
-		doc := documentsMap[locations[i].ID]
-		locations[i].VectorDistance = doc.VectorDistance
-		locations[i].TextMatchScore = doc.TextMatchScore
+	locations := make([]*entity.Locations, 0, len(documents))
+	for _, doc := range documents {
+		locations = append(locations, &entity.Locations{
+			ID:      doc.ID,
+			City:    doc.City,
+			State:   doc.State,
+			Country: doc.Country,
+			Code:    doc.Code,
+			Lat:     doc.Lat,
+			Lng:     doc.Lng,
+		})
 	}
 
-	// === 7. Compute FusionScore and sort ===
-	for i := range locations {
-		locations[i].RankFusionScore = pointer.Float64OrNil(computeFusionScore(locations[i]))
-	}
+	return locations, nil
+}
 
-	sort.SliceStable(locations, func(i, j int) bool {
-		if locations[i].RankFusionScore == nil {
-			return false
-		}
+func (s *service) ReverseGeocode(ctx context.Context, lat, lng float64, limit uint) ([]*entity.Locations, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextDeadline)
+	defer cancel()
 
-		if locations[j].RankFusionScore == nil {
-			return true
-		}
+	if limit == 0 {
+		limit = 5
+	}
 
-		return *locations[i].RankFusionScore > *locations[j].RankFusionScore
+	// === 1. Find nearest documents in typesense ===
+	locationIDs, documentsMap, err := s.typesenseAPI.GeoSearchLocations(ctx, typesense.GeoSearchRequest{
+		Lat:   lat,
+		Lng:   lng,
+		Limit: int(limit),
 	})
-
-	for _, v := range locations {
-		fmt.Printf("%s (tranlited %s): city: %s fusion score: %f\n", query, transliteratedQuery, v.City, pointer.Float64Value(v.RankFusionScore))
+	if err != nil {
+		return nil, inerr.Err(err)
 	}
 
-	return locations, nil
-}
-
-func computeFusionScore(loc *entity.Locations) float64 {
-	var (
-		vectorScore float64
-		textScore   float64
-	)
+	if len(locationIDs) == 0 {
+		return []*entity.Locations{}, nil
+	}
 
-	// Normalize vector distance (lower is better)
-	if loc.VectorDistance != nil {
-		vectorScore = float64(1.0 - *loc.VectorDistance)
+	// === 2. Fetch matched location entities from DB ===
+	_, locations, err := s.locationRepo.FindAll(ctx, 0, 1, "", map[string]any{"id": locationIDs})
+	if err != nil {
+		return nil, inerr.Err(err)
 	}
 
-	// Normalize text_match_score using logarithmic scale
-	if loc.TextMatchScore != nil && *loc.TextMatchScore > 0 {
-		textScore = math.Log10(float64(*loc.TextMatchScore))
-		// Optional: normalize it to a 0.0 - 1.0 range based on expected max
-		textScore = textScore / 20.0 // assume max score ~1e20
+	// === 3. Inject distances and restore typesense (nearest first) order ===
+	for i := range locations {
+		locations[i].DistanceMeters = documentsMap[locations[i].ID].GeoDistanceMeters
 	}
 
-	// Clamp values to [0.0, 1.0]
-	if textScore > 1.0 {
-		textScore = 1.0
+	return orderByIDs(locations, locationIDs), nil
+}
+
+// orderByIDs returns locations in the same order as ids, skipping ids that were not found
+func orderByIDs(locations []*entity.Locations, ids []int64) []*entity.Locations {
+	byID := make(map[int64]*entity.Locations, len(locations))
+	for _, l := range locations {
+		byID[l.ID] = l
 	}
 
-	// Weighted sum (tune these)
-	const alpha = 0.3 // weight for vector match
-	const beta = 0.7  // weight for text match
+	ordered := make([]*entity.Locations, 0, len(locations))
+	for _, id := range ids {
+		if l, ok := byID[id]; ok {
+			ordered = append(ordered, l)
+		}
+	}
 
-	return alpha*vectorScore + beta*textScore
+	return ordered
 }
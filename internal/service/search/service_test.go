@@ -0,0 +1,260 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/locations"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/typesense"
+	"github.com/shogo82148/pointer"
+)
+
+// fakeLocationRepo returns the stored locations matching the "id" condition in reverse,
+// so that results only come out right if the service restores the order itself
+type fakeLocationRepo struct {
+	locations.Repository
+	rows []*entity.Locations
+}
+
+func (r *fakeLocationRepo) FindAll(ctx context.Context, limit, page uint64, orderBy string, filter map[string]any, preloads ...string) (uint64, []*entity.Locations, error) {
+	ids, _ := filter["id"].([]int64)
+
+	var result []*entity.Locations
+	for i := len(r.rows) - 1; i >= 0; i-- {
+		if slices.Contains(ids, r.rows[i].ID) {
+			// Fresh copies, like rows read from the database
+			location := *r.rows[i]
+			result = append(result, &location)
+		}
+	}
+
+	return uint64(len(result)), result, nil
+}
+
+type fakeTypesense struct {
+	typesense.Client
+	ids       []int64
+	documents map[int64]typesense.Locations
+}
+
+func (c *fakeTypesense) MultiHybridSearchLocations(ctx context.Context, queries []typesense.MultiHybridSearchRequest) ([]int64, map[int64]typesense.Locations, error) {
+	return c.ids, c.documents, nil
+}
+
+type fakeEmbeddings struct {
+	err   error
+	delay time.Duration
+}
+
+func (c fakeEmbeddings) Generate(ctx context.Context, text string) ([]float64, error) {
+	if err := wait(ctx, c.delay); err != nil {
+		return nil, err
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return []float64{1, 0}, nil
+}
+
+func (c fakeEmbeddings) GenerateBatch(ctx context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, len(texts))
+	for i := range texts {
+		vector, err := c.Generate(ctx, texts[i])
+		if err != nil {
+			return nil, err
+		}
+		vectors[i] = vector
+	}
+	return vectors, nil
+}
+
+func (fakeEmbeddings) Dimensions() int { return 2 }
+
+func (fakeEmbeddings) Model() string { return "fake" }
+
+type fakeTransliterator struct {
+	err   error
+	delay time.Duration
+}
+
+func (t fakeTransliterator) Transliterate(ctx context.Context, text string) (string, error) {
+	if err := wait(ctx, t.delay); err != nil {
+		return "", err
+	}
+	if t.err != nil {
+		return "", t.err
+	}
+	return text, nil
+}
+
+// wait simulates a slow dependency that gives up when the context is done
+func wait(ctx context.Context, delay time.Duration) error {
+	if delay == 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newTestService(ids []int64, documents map[int64]typesense.Locations) Service {
+	rows := make([]*entity.Locations, len(ids))
+	for i, id := range ids {
+		rows[i] = &entity.Locations{ID: id, City: "Springfield"}
+	}
+
+	return New(
+		time.Second,
+		time.Second,
+		DefaultRankingConfig(),
+		&fakeLocationRepo{rows: rows},
+		fakeEmbeddings{},
+		&fakeTypesense{ids: ids, documents: documents},
+		fakeTransliterator{},
+		nil,
+	)
+}
+
+func TestSearchTopResultDoesNotDependOnLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		ids       []int64
+		documents map[int64]typesense.Locations
+		wantTop   int64
+	}{
+		{
+			name: "all scores tie",
+			ids:  []int64{7, 3, 5, 1},
+			documents: map[int64]typesense.Locations{
+				1: {TextMatchScore: pointer.Int64(1000)},
+				3: {TextMatchScore: pointer.Int64(1000)},
+				5: {TextMatchScore: pointer.Int64(1000)},
+				7: {TextMatchScore: pointer.Int64(1000)},
+			},
+			wantTop: 7,
+		},
+		{
+			name: "best score is not first in typesense order",
+			ids:  []int64{7, 3, 5, 1},
+			documents: map[int64]typesense.Locations{
+				1: {TextMatchScore: pointer.Int64(1000)},
+				3: {TextMatchScore: pointer.Int64(1000), VectorDistance: pointer.Float32(0.1)},
+				5: {TextMatchScore: pointer.Int64(1000), VectorDistance: pointer.Float32(0.1)},
+				7: {TextMatchScore: pointer.Int64(1000)},
+			},
+			wantTop: 3,
+		},
+		{
+			name: "best score is last",
+			ids:  []int64{2, 4, 6},
+			documents: map[int64]typesense.Locations{
+				2: {TextMatchScore: pointer.Int64(10)},
+				4: {TextMatchScore: pointer.Int64(10)},
+				6: {TextMatchScore: pointer.Int64(1000000)},
+			},
+			wantTop: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(tt.ids, tt.documents)
+
+			var full []int64
+			for limit := uint(len(tt.ids)); limit >= 1; limit-- {
+				result, err := s.Search(context.Background(), "springfield", limit, entity.SearchOptions{})
+				if err != nil {
+					t.Fatalf("Search(limit=%d) error = %v", limit, err)
+				}
+
+				got := locationIDs(result.Locations)
+				if uint(len(got)) != limit {
+					t.Fatalf("Search(limit=%d) returned %d locations", limit, len(got))
+				}
+
+				if full == nil {
+					full = got
+				} else if !equalIDs(got, full[:limit]) {
+					t.Errorf("Search(limit=%d) = %v, want the first %d of %v", limit, got, limit, full)
+				}
+
+				if got[0] != tt.wantTop {
+					t.Errorf("Search(limit=%d) top = %d, want %d", limit, got[0], tt.wantTop)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchDegradesWhenDependenciesFail(t *testing.T) {
+	ids := []int64{1, 2}
+	documents := map[int64]typesense.Locations{
+		1: {TextMatchScore: pointer.Int64(10)},
+		2: {TextMatchScore: pointer.Int64(1000)},
+	}
+
+	s := New(
+		time.Second,
+		time.Second,
+		DefaultRankingConfig(),
+		&fakeLocationRepo{rows: newLocations(ids...)},
+		fakeEmbeddings{err: errors.New("embeddings down")},
+		&fakeTypesense{ids: ids, documents: documents},
+		fakeTransliterator{err: errors.New("transliterator down")},
+		nil,
+	)
+
+	result, err := s.Search(context.Background(), "springfield", 1, entity.SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if !result.Degraded {
+		t.Error("Search() Degraded = false, want true")
+	}
+
+	if got := locationIDs(result.Locations); !equalIDs(got, []int64{2}) {
+		t.Errorf("Search() = %v, want [2]", got)
+	}
+}
+
+func TestSearchDegradesWithinDependencyBudget(t *testing.T) {
+	ids := []int64{1}
+	documents := map[int64]typesense.Locations{1: {TextMatchScore: pointer.Int64(10)}}
+
+	budget := 50 * time.Millisecond
+	s := New(
+		time.Minute,
+		budget,
+		DefaultRankingConfig(),
+		&fakeLocationRepo{rows: newLocations(ids...)},
+		fakeEmbeddings{delay: time.Minute},
+		&fakeTypesense{ids: ids, documents: documents},
+		fakeTransliterator{delay: time.Minute},
+		nil,
+	)
+
+	started := time.Now()
+	result, err := s.Search(context.Background(), "springfield", 1, entity.SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	// The dependencies would hang for a minute, the budget must cut them short
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("Search() took %s, want about %s", elapsed, budget)
+	}
+
+	want := []entity.DegradedReason{entity.DegradedReasonTransliteration, entity.DegradedReasonEmbeddings}
+	if !result.Degraded || !slices.Equal(result.DegradedReasons, want) {
+		t.Errorf("Search() degraded = %v %v, want true %v", result.Degraded, result.DegradedReasons, want)
+	}
+}
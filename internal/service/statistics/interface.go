@@ -0,0 +1,17 @@
+package statistics
+
+import (
+	"context"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+type Service interface {
+	// Record queues a search log to be saved in the background, dropping it when the queue is full
+	Record(log *entity.SearchLogs)
+	// GetStatistics aggregates searches made in [from, to), limit caps every top list
+	GetStatistics(ctx context.Context, from, to time.Time, limit int) (*entity.SearchStatistics, error)
+	// Close saves queued logs and stops the background writer
+	Close()
+}
@@ -0,0 +1,148 @@
+package statistics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/infrasturcture/repository/searchlogs"
+	"github.com/AsaHero/whereismycity/pkg/database/postgres"
+	"github.com/AsaHero/whereismycity/pkg/logger"
+)
+
+const (
+	// queueSize is how many logs may wait to be saved before new ones are dropped
+	queueSize = 1000
+	// flushSize and flushInterval bound how long logs wait in memory
+	flushSize     = 100
+	flushInterval = 2 * time.Second
+)
+
+type service struct {
+	contextTimeout time.Duration
+	searchLogRepo  searchlogs.Repository
+
+	queue     chan *entity.SearchLogs
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func New(contextTimeout time.Duration, searchLogRepo searchlogs.Repository) Service {
+	s := &service{
+		contextTimeout: contextTimeout,
+		searchLogRepo:  searchLogRepo,
+		queue:          make(chan *entity.SearchLogs, queueSize),
+		done:           make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+func (s *service) Record(log *entity.SearchLogs) {
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+
+	select {
+	case s.queue <- log:
+	default:
+		logger.Warn("search log queue is full, dropping search log")
+	}
+}
+
+func (s *service) Close() {
+	s.closeOnce.Do(func() {
+		close(s.queue)
+		<-s.done
+	})
+}
+
+// run saves queued logs in batches until the queue is closed
+func (s *service) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*entity.SearchLogs, 0, flushSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.contextTimeout)
+		defer cancel()
+
+		if err := s.searchLogRepo.BatchCreate(ctx, batch); err != nil {
+			logger.Error(fmt.Sprintf("failed to save %d search logs: %v", len(batch), err))
+		}
+		batch = make([]*entity.SearchLogs, 0, flushSize)
+	}
+
+	for {
+		select {
+		case log, ok := <-s.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, log)
+			if len(batch) >= flushSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (s *service) GetStatistics(ctx context.Context, from, to time.Time, limit int) (*entity.SearchStatistics, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	period := func() map[string]any {
+		return map[string]any{
+			"search_logs.created_at": postgres.TimeCondition{
+				postgres.OpGreaterThanOrEqual: from,
+				postgres.OpLessThan:           to,
+			},
+		}
+	}
+
+	statistics := &entity.SearchStatistics{
+		From: from,
+		To:   to,
+	}
+
+	var err error
+	if statistics.PerDay, err = s.searchLogRepo.SearchesPerDay(ctx, period()); err != nil {
+		return nil, err
+	}
+	for _, day := range statistics.PerDay {
+		statistics.TotalSearches += day.Searches
+	}
+
+	if statistics.TopQueries, err = s.searchLogRepo.TopQueries(ctx, period(), limit); err != nil {
+		return nil, err
+	}
+
+	zeroResults := period()
+	zeroResults["search_logs.result_count"] = 0
+	if statistics.ZeroResultQueries, err = s.searchLogRepo.TopQueries(ctx, zeroResults, limit); err != nil {
+		return nil, err
+	}
+
+	if statistics.Latency, err = s.searchLogRepo.LatencyPercentiles(ctx, period()); err != nil {
+		return nil, err
+	}
+
+	if statistics.PerUser, err = s.searchLogRepo.SearchesPerUser(ctx, period(), limit); err != nil {
+		return nil, err
+	}
+
+	return statistics, nil
+}
@@ -2,6 +2,7 @@ package users
 
 import (
 	"context"
+	"time"
 
 	"github.com/AsaHero/whereismycity/internal/entity"
 )
@@ -11,5 +12,12 @@ type Service interface {
 	GetByID(ctx context.Context, id string) (*entity.Users, error)
 	List(ctx context.Context, limit, page uint64, filterOptions *entity.UserFilterOptions, sortOptions *entity.SortOptions) (int64, []*entity.Users, error)
 	Update(ctx context.Context, user *entity.Users) error
+	// Delete soft deletes a user, it can be restored until it is purged
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (*entity.Users, error)
+	// Purge permanently removes users deleted before the given time
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	// SetStatus changes the status of a user. Reason and until only apply to
+	// suspended and inactive users and are cleared on activation
+	SetStatus(ctx context.Context, id string, status entity.UserStatus, reason *string, until *time.Time) (*entity.Users, error)
 }
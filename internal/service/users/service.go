@@ -9,15 +9,23 @@ import (
 	"github.com/google/uuid"
 )
 
+// CredentialInvalidator forgets cached credentials of a user whose account changed
+type CredentialInvalidator interface {
+	InvalidateUser(userID string)
+}
+
 type service struct {
 	contextTimeout time.Duration
 	userRepo       users.Repository
+	invalidator    CredentialInvalidator
 }
 
-func New(contextTimeout time.Duration, userRepo users.Repository) Service {
+// New creates the users service, invalidator may be nil when credentials aren't cached
+func New(contextTimeout time.Duration, userRepo users.Repository, invalidator CredentialInvalidator) Service {
 	return &service{
 		contextTimeout: contextTimeout,
 		userRepo:       userRepo,
+		invalidator:    invalidator,
 	}
 }
 
@@ -63,6 +71,9 @@ func (s *service) Update(ctx context.Context, user *entity.Users) error {
 		return err
 	}
 
+	// Any update may change the password, role or status the cached credentials vouch for
+	s.invalidate(user.ID)
+
 	return nil
 }
 
@@ -74,14 +85,62 @@ func (s *service) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
+	s.invalidate(id)
+
 	return nil
 }
 
+func (s *service) Restore(ctx context.Context, id string) (*entity.Users, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	if err := s.userRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.FindOne(ctx, map[string]any{"id": id})
+}
+
+func (s *service) Purge(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	return s.userRepo.Purge(ctx, before)
+}
+
+func (s *service) SetStatus(ctx context.Context, id string, status entity.UserStatus, reason *string, until *time.Time) (*entity.Users, error) {
+	user, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Status = status
+	user.StatusReason = reason
+	user.SuspendedUntil = nil
+
+	switch status {
+	case entity.UserStatusActive, entity.UserStatusPending:
+		user.StatusReason = nil
+	case entity.UserStatusSuspended:
+		user.SuspendedUntil = until
+	}
+
+	if err := s.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
 func (service) beforeCreate(user *entity.Users) {
 	if user.ID == "" {
 		user.ID = uuid.New().String()
 	}
 
+	if user.Status == "" {
+		user.Status = entity.UserStatusActive
+	}
+
 	if user.CreatedAt.IsZero() {
 		user.CreatedAt = time.Now()
 	}
@@ -94,3 +153,9 @@ func (service) beforeCreate(user *entity.Users) {
 func (service) beforeUpdate(user *entity.Users) {
 	user.UpdatedAt = time.Now()
 }
+
+func (s *service) invalidate(userID string) {
+	if s.invalidator != nil {
+		s.invalidator.InvalidateUser(userID)
+	}
+}
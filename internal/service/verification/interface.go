@@ -0,0 +1,15 @@
+package verification
+
+import (
+	"context"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+)
+
+type Service interface {
+	// Send mails a verification link for the current email of the user
+	Send(ctx context.Context, user *entity.Users) error
+	// Resend mails a new verification link unless the email is already verified
+	Resend(ctx context.Context, userID string) error
+	Verify(ctx context.Context, token string) error
+}
@@ -0,0 +1,116 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AsaHero/whereismycity/internal/entity"
+	"github.com/AsaHero/whereismycity/internal/inerr"
+	"github.com/AsaHero/whereismycity/internal/service/users"
+	"github.com/AsaHero/whereismycity/pkg/mailer"
+	"github.com/AsaHero/whereismycity/pkg/security"
+)
+
+const mailBody = `Hello %s,
+
+Please confirm your email address by opening the link below:
+
+%s
+
+The link expires in %s. If you didn't create an account, ignore this email.
+`
+
+type service struct {
+	contextTimeout time.Duration
+	secret         string
+	tokenTTL       time.Duration
+	appURL         string
+	userService    users.Service
+	mailer         mailer.Mailer
+}
+
+func New(contextTimeout time.Duration, secret string, tokenTTL time.Duration, appURL string, userService users.Service, mailer mailer.Mailer) Service {
+	return &service{
+		contextTimeout: contextTimeout,
+		secret:         secret,
+		tokenTTL:       tokenTTL,
+		appURL:         appURL,
+		userService:    userService,
+		mailer:         mailer,
+	}
+}
+
+func (s *service) Send(ctx context.Context, user *entity.Users) error {
+	ctx, cancel := context.WithTimeout(ctx, s.contextTimeout)
+	defer cancel()
+
+	token, err := security.GenerateEmailVerificationToken(user.ID, user.Email, s.tokenTTL, s.secret)
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your email address",
+		Body:    fmt.Sprintf(mailBody, user.Name, s.link(token), s.tokenTTL),
+	})
+	if err != nil {
+		return inerr.Err(err)
+	}
+
+	return nil
+}
+
+func (s *service) Resend(ctx context.Context, userID string) error {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return inerr.ErrorEmailAlreadyVerified
+	}
+
+	return s.Send(ctx, user)
+}
+
+func (s *service) Verify(ctx context.Context, token string) error {
+	claims, err := security.ParseEmailVerificationToken(token, s.secret)
+	if err != nil {
+		return fmt.Errorf("%w: %s", inerr.ErrorInvalidVerificationToken, err.Error())
+	}
+
+	user, err := s.userService.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if inerr.IsErrNotFound(err) {
+			return inerr.ErrorInvalidVerificationToken
+		}
+		return err
+	}
+
+	// The address changed after the token was sent
+	if !strings.EqualFold(user.Email, claims.Email) {
+		return inerr.ErrorInvalidVerificationToken
+	}
+
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+
+	return s.userService.Update(ctx, user)
+}
+
+// link points to the verification page of the app, or is the bare token when no app URL is configured
+func (s *service) link(token string) string {
+	if s.appURL == "" {
+		return token
+	}
+
+	return strings.TrimRight(s.appURL, "/") + "/verify-email?token=" + url.QueryEscape(token)
+}
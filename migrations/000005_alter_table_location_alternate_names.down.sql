@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_location_alternate_names_alternate_name_id;
+
+CREATE INDEX IF NOT EXISTS idx_location_alternate_names_alternate_name_id ON location_alternate_names(alternate_name_id);
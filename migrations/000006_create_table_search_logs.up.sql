@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS search_logs(
+    id bigserial,
+    user_id uuid,
+    query character varying(255) NOT NULL,
+    transliteration character varying(255) NOT NULL DEFAULT '',
+    filters jsonb,
+    result_count integer NOT NULL DEFAULT 0,
+    top_location_id bigint,
+    latency_ms bigint NOT NULL DEFAULT 0,
+    degraded boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT search_logs_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_logs_created_at ON search_logs(created_at);
+
+CREATE INDEX IF NOT EXISTS idx_search_logs_user_id ON search_logs(user_id);
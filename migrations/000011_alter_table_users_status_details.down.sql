@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
+
+ALTER TABLE users DROP COLUMN IF EXISTS status_reason;
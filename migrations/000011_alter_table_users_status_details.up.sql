@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_reason character varying(500);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until timestamp with time zone;
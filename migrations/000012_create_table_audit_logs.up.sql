@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS audit_logs(
+    id bigserial,
+    actor_id uuid,
+    actor_role character varying(50),
+    action character varying(100) NOT NULL,
+    target_type character varying(50) NOT NULL DEFAULT '',
+    target_id character varying(255),
+    changes jsonb,
+    metadata jsonb,
+    ip character varying(64) NOT NULL DEFAULT '',
+    user_agent character varying(512) NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT audit_logs_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs(actor_id);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(action);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs(target_type, target_id);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS role_permissions(
+    role character varying(50) NOT NULL,
+    permission character varying(100) NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (role, permission)
+);
+
+-- Defaults keep every role's access as it was with role checks
+INSERT INTO role_permissions(role, permission) VALUES
+    ('admin', 'search:read'),
+    ('admin', 'search:explain'),
+    ('admin', 'locations:read'),
+    ('admin', 'users:read'),
+    ('admin', 'users:write'),
+    ('admin', 'stats:read'),
+    ('admin', 'audit:read'),
+    ('admin', 'roles:read'),
+    ('admin', 'roles:write'),
+    ('user', 'search:read'),
+    ('user', 'locations:read'),
+    ('guest', 'search:read'),
+    ('guest', 'locations:read')
+ON CONFLICT DO NOTHING;
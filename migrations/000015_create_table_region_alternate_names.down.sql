@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_region_alternate_names_country_code;
+
+DROP INDEX IF EXISTS idx_region_alternate_names_alternate_name_id;
+
+DROP TABLE IF EXISTS region_alternate_names CASCADE;
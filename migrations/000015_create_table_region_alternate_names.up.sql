@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS region_alternate_names(
+    id bigserial PRIMARY KEY,
+    geoname_id bigint NOT NULL,
+    alternate_name_id bigint NOT NULL,
+    region_type varchar(20) NOT NULL,
+    country_code varchar(3) NOT NULL,
+    name varchar(255) NOT NULL,
+    iso_language_code varchar(10) NOT NULL,
+    alternate_name text NOT NULL,
+    is_preferred boolean DEFAULT FALSE,
+    is_short boolean DEFAULT FALSE,
+    is_colloquial boolean DEFAULT FALSE,
+    is_historic boolean DEFAULT FALSE,
+    created_at timestamp with time zone DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_region_alternate_names_alternate_name_id ON region_alternate_names(alternate_name_id);
+
+CREATE INDEX IF NOT EXISTS idx_region_alternate_names_country_code ON region_alternate_names(country_code, region_type);
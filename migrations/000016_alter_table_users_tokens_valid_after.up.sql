@@ -0,0 +1,2 @@
+-- Access tokens issued before tokens_valid_after are rejected, logging out everywhere moves it forward
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tokens_valid_after timestamp with time zone;
@@ -48,12 +48,13 @@ type Config struct {
 	}
 
 	Typesense struct {
-		APIKey        string
-		Host          string
-		Port          string
-		RetryCount    int
-		RetryWaitTime string
-		Timeout       string
+		APIKey              string
+		Host                string
+		Port                string
+		RetryCount          int
+		RetryWaitTime       string
+		Timeout             string
+		AutocompleteTimeout string
 	}
 
 	OpenAI struct {
@@ -61,6 +62,85 @@ type Config struct {
 		Timeout string
 	}
 
+	Embeddings struct {
+		// Provider is one of openai, openai_compatible or local
+		Provider   string
+		Model      string
+		BaseURL    string
+		APIKey     string
+		Dimensions string
+		// Cache is one of redis, memory or none
+		Cache     string
+		CacheSize string
+	}
+
+	Search struct {
+		// DependencyTimeout bounds transliteration and embeddings calls, search degrades once it runs out
+		DependencyTimeout string
+	}
+
+	Ranking struct {
+		// Strategy is one of weighted, typesense, rrf or minmax
+		Strategy     string
+		VectorWeight string
+		TextWeight   string
+		RRFK         string
+		// RRFWeights are comma separated weights of the original and transliterated result lists
+		RRFWeights string
+	}
+
+	RateLimit struct {
+		// Store is one of redis, memory or none
+		Store string
+		// Limits are "<requests>/<period>", e.g. 60/1m, empty means unlimited
+		Admin     string
+		User      string
+		Guest     string
+		Anonymous string
+		// AuthIP limits the requests of an IP ahead of Basic, Bearer and API key authentication
+		AuthIP string
+	}
+
+	Auth struct {
+		// RegistrationStatus is given to self-registered users: active, or pending to require admin approval
+		RegistrationStatus string
+		// CredentialCacheTTL is how long verified basic auth credentials are trusted, 0 disables the cache
+		CredentialCacheTTL  string
+		CredentialCacheSize string
+		// PermissionCacheTTL is how long the role permissions are kept in memory before they are read again
+		PermissionCacheTTL string
+	}
+
+	Users struct {
+		// DeletedRetention is how long deleted users can be restored before they are purged, 0 disables the purge
+		DeletedRetention string
+		PurgeInterval    string
+	}
+
+	Mailer struct {
+		// Driver is one of smtp, file or log (MAILER_DRIVER). It defaults to log outside production,
+		// production has no default and requires smtp with MAILER_SMTP_HOST
+		Driver       string
+		From         string
+		SMTPHost     string
+		SMTPPort     string
+		SMTPUsername string
+		SMTPPassword string
+		// Dir is where the file driver writes messages
+		Dir string
+	}
+
+	EmailVerification struct {
+		TokenTTL string
+		// Restrict lists the route groups unverified users can't call: search, api_keys. Empty by default,
+		// restricting needs a mailer that actually delivers the verification mail
+		Restrict string
+	}
+
+	PasswordReset struct {
+		TokenTTL string
+	}
+
 	Token struct {
 		Secret string
 	}
@@ -116,10 +196,66 @@ func New() *Config {
 	config.Typesense.RetryCount = 3
 	config.Typesense.RetryWaitTime = "1s"
 	config.Typesense.Timeout = getEnv("TYPESENSE_TIMEOUT", "30s")
+	config.Typesense.AutocompleteTimeout = getEnv("TYPESENSE_AUTOCOMPLETE_TIMEOUT", "300ms")
 
 	// embeddings configuration
 	config.OpenAI.APIKey = getEnv("OPENAI_API_KEY", "")
 	config.OpenAI.Timeout = getEnv("OPENAI_TIMEOUT", "30s")
+	config.Embeddings.Provider = getEnv("EMBEDDINGS_PROVIDER", "openai")
+	config.Embeddings.Model = getEnv("EMBEDDINGS_MODEL", "")
+	config.Embeddings.BaseURL = getEnv("EMBEDDINGS_BASE_URL", "")
+	config.Embeddings.APIKey = getEnv("EMBEDDINGS_API_KEY", "")
+	config.Embeddings.Dimensions = getEnv("EMBEDDINGS_DIMENSIONS", "")
+	config.Embeddings.Cache = getEnv("EMBEDDINGS_CACHE", "memory")
+	config.Embeddings.CacheSize = getEnv("EMBEDDINGS_CACHE_SIZE", "10000")
+
+	// search configuration
+	config.Search.DependencyTimeout = getEnv("SEARCH_DEPENDENCY_TIMEOUT", "2s")
+
+	// ranking configuration
+	config.Ranking.Strategy = getEnv("RANKING_STRATEGY", "weighted")
+	config.Ranking.VectorWeight = getEnv("RANKING_VECTOR_WEIGHT", "0.3")
+	config.Ranking.TextWeight = getEnv("RANKING_TEXT_WEIGHT", "0.7")
+	config.Ranking.RRFK = getEnv("RANKING_RRF_K", "60")
+	config.Ranking.RRFWeights = getEnv("RANKING_RRF_WEIGHTS", "1,1")
+
+	// rate limit configuration
+	config.RateLimit.Store = getEnv("RATE_LIMIT_STORE", "memory")
+	config.RateLimit.Admin = getEnv("RATE_LIMIT_ADMIN", "")
+	config.RateLimit.User = getEnv("RATE_LIMIT_USER", "60/1m")
+	config.RateLimit.Guest = getEnv("RATE_LIMIT_GUEST", "20/1m")
+	config.RateLimit.Anonymous = getEnv("RATE_LIMIT_ANONYMOUS", "10/1m")
+	config.RateLimit.AuthIP = getEnv("RATE_LIMIT_AUTH_IP", "120/1m")
+
+	// auth configuration
+	config.Auth.RegistrationStatus = getEnv("AUTH_REGISTRATION_STATUS", "active")
+	config.Auth.CredentialCacheTTL = getEnv("AUTH_CREDENTIAL_CACHE_TTL", "30s")
+	config.Auth.CredentialCacheSize = getEnv("AUTH_CREDENTIAL_CACHE_SIZE", "10000")
+	config.Auth.PermissionCacheTTL = getEnv("AUTH_PERMISSION_CACHE_TTL", "30s")
+
+	// users configuration
+	config.Users.DeletedRetention = getEnv("USERS_DELETED_RETENTION", "720h")
+	config.Users.PurgeInterval = getEnv("USERS_PURGE_INTERVAL", "1h")
+
+	// mailer configuration
+	mailerDriver := "log"
+	if config.Environment == Production {
+		mailerDriver = ""
+	}
+	config.Mailer.Driver = getEnv("MAILER_DRIVER", mailerDriver)
+	config.Mailer.From = getEnv("MAILER_FROM", "no-reply@whereismycity.local")
+	config.Mailer.SMTPHost = getEnv("MAILER_SMTP_HOST", "")
+	config.Mailer.SMTPPort = getEnv("MAILER_SMTP_PORT", "587")
+	config.Mailer.SMTPUsername = getEnv("MAILER_SMTP_USERNAME", "")
+	config.Mailer.SMTPPassword = getEnv("MAILER_SMTP_PASSWORD", "")
+	config.Mailer.Dir = getEnv("MAILER_DIR", "mails")
+
+	// email verification configuration
+	config.EmailVerification.TokenTTL = getEnv("EMAIL_VERIFICATION_TOKEN_TTL", "24h")
+	config.EmailVerification.Restrict = getEnv("EMAIL_VERIFICATION_RESTRICT", "")
+
+	// password reset configuration
+	config.PasswordReset.TokenTTL = getEnv("PASSWORD_RESET_TOKEN_TTL", "1h")
 
 	// token configuration
 	config.Token.Secret = getEnv("TOKEN_SECRET", "secret")
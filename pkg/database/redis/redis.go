@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/AsaHero/whereismycity/pkg/config"
+	"github.com/redis/go-redis/v9"
+)
+
+func New(cfg *config.Config) (*redis.Client, error) {
+	db, err := strconv.Atoi(cfg.Redis.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis db: %w", err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       db,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,187 @@
+package geonames
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxLineSize is large enough for the longest lines of allCountries.txt (alternate names column)
+const maxLineSize = 1024 * 1024
+
+// Geoname is a row of the GeoNames main dump (allCountries.txt, cities*.txt)
+type Geoname struct {
+	GeonameID    int64
+	Name         string
+	ASCIIName    string
+	Lat          float64
+	Lng          float64
+	FeatureClass string
+	FeatureCode  string
+	CountryCode  string
+	Admin1Code   string
+	Population   int64
+}
+
+// AlternateName is a row of alternateNamesV2.txt
+type AlternateName struct {
+	AlternateNameID int64
+	GeonameID       int64
+	ISOLanguage     string
+	Name            string
+	IsPreferred     bool
+	IsShort         bool
+	IsColloquial    bool
+	IsHistoric      bool
+}
+
+// Region is a row of admin1CodesASCII.txt or countryInfo.txt
+type Region struct {
+	GeonameID int64
+	// Code is "CC.ADMIN1" for first-level divisions and the ISO code for countries
+	Code string
+	Name string
+}
+
+// ReadGeonames reads a GeoNames main dump and calls fn for every row
+func ReadGeonames(r io.Reader, fn func(Geoname) error) error {
+	return readLines(r, 15, func(fields []string) error {
+		geonameID, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid geonameid %q: %w", fields[0], err)
+		}
+
+		lat, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			return fmt.Errorf("invalid latitude %q: %w", fields[4], err)
+		}
+
+		lng, err := strconv.ParseFloat(fields[5], 64)
+		if err != nil {
+			return fmt.Errorf("invalid longitude %q: %w", fields[5], err)
+		}
+
+		// Population is optional in the dump
+		population, _ := strconv.ParseInt(fields[14], 10, 64)
+
+		return fn(Geoname{
+			GeonameID:    geonameID,
+			Name:         fields[1],
+			ASCIIName:    fields[2],
+			Lat:          lat,
+			Lng:          lng,
+			FeatureClass: fields[6],
+			FeatureCode:  fields[7],
+			CountryCode:  fields[8],
+			Admin1Code:   fields[10],
+			Population:   population,
+		})
+	})
+}
+
+// ReadAlternateNames reads alternateNamesV2.txt and calls fn for every row
+func ReadAlternateNames(r io.Reader, fn func(AlternateName) error) error {
+	return readLines(r, 8, func(fields []string) error {
+		alternateNameID, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid alternateNameId %q: %w", fields[0], err)
+		}
+
+		geonameID, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid geonameid %q: %w", fields[1], err)
+		}
+
+		return fn(AlternateName{
+			AlternateNameID: alternateNameID,
+			GeonameID:       geonameID,
+			ISOLanguage:     fields[2],
+			Name:            fields[3],
+			IsPreferred:     fields[4] == "1",
+			IsShort:         fields[5] == "1",
+			IsColloquial:    fields[6] == "1",
+			IsHistoric:      fields[7] == "1",
+		})
+	})
+}
+
+// ReadAdmin1Codes reads admin1CodesASCII.txt into a map of "CC.ADMIN1" to the region
+func ReadAdmin1Codes(r io.Reader) (map[string]Region, error) {
+	codes := make(map[string]Region)
+
+	err := readLines(r, 2, func(fields []string) error {
+		codes[fields[0]] = Region{
+			GeonameID: optionalID(fields, 3),
+			Code:      fields[0],
+			Name:      fields[1],
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// ReadCountryInfo reads countryInfo.txt into a map of ISO country code to the country
+func ReadCountryInfo(r io.Reader) (map[string]Region, error) {
+	countries := make(map[string]Region)
+
+	err := readLines(r, 5, func(fields []string) error {
+		countries[fields[0]] = Region{
+			GeonameID: optionalID(fields, 16),
+			Code:      fields[0],
+			Name:      fields[4],
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return countries, nil
+}
+
+// optionalID parses the geonameid column at index, returning 0 when it is missing or empty
+func optionalID(fields []string, index int) int64 {
+	if index >= len(fields) {
+		return 0
+	}
+
+	id, _ := strconv.ParseInt(fields[index], 10, 64)
+	return id
+}
+
+// readLines splits tab separated lines, skipping empty lines and comments
+func readLines(r io.Reader, minFields int, fn func(fields []string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := scanner.Text()
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) < minFields {
+			return fmt.Errorf("line %d: expected at least %d fields, got %d", line, minFields, len(fields))
+		}
+
+		if err := fn(fields); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("line %d: %w", line, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package lru
+
+import "container/list"
+
+// Cache is a least recently used cache holding at most size entries.
+// It is not safe for concurrent use, callers guard it with their own lock
+type Cache[K comparable, V any] struct {
+	size    int
+	order   *list.List
+	items   map[K]*list.Element
+	onEvict func(key K, value V)
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// New creates a cache of at least one entry. onEvict, when not nil, is called for
+// entries dropped to make room, not for the ones removed or replaced by the caller
+func New[K comparable, V any](size int, onEvict func(key K, value V)) *Cache[K, V] {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &Cache[K, V]{
+		size:    size,
+		order:   list.New(),
+		items:   make(map[K]*list.Element, size),
+		onEvict: onEvict,
+	}
+}
+
+// Get returns the value of the key and marks it as recently used
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	element, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*entry[K, V]).value, true
+}
+
+// Set adds or replaces the value of the key, evicting the least recently used entry when the cache is full
+func (c *Cache[K, V]) Set(key K, value V) {
+	if element, ok := c.items[key]; ok {
+		element.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Remove(c.order.Back()).(*entry[K, V])
+		delete(c.items, oldest.key)
+
+		if c.onEvict != nil {
+			c.onEvict(oldest.key, oldest.value)
+		}
+	}
+}
+
+// Remove drops the key and returns the value it held
+func (c *Cache[K, V]) Remove(key K) (V, bool) {
+	element, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	c.order.Remove(element)
+	delete(c.items, key)
+
+	return element.Value.(*entry[K, V]).value, true
+}
+
+func (c *Cache[K, V]) Len() int {
+	return c.order.Len()
+}
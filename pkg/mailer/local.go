@@ -0,0 +1,60 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AsaHero/whereismycity/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// fileMailer writes every message as an .eml file, for local development
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFile(dir, from string) (Mailer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file mailer requires a directory")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	return &fileMailer{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+func (m *fileMailer) Send(_ context.Context, msg Message) error {
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), filepath.Base(msg.To))
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), compose(m.from, msg), 0o600); err != nil {
+		return fmt.Errorf("failed to write mail: %w", err)
+	}
+
+	return nil
+}
+
+// logMailer only logs that a message was sent, mail never leaves the process.
+// Bodies carry verification and reset tokens, so they are never logged
+type logMailer struct{}
+
+func NewLog() Mailer {
+	return logMailer{}
+}
+
+func (logMailer) Send(_ context.Context, msg Message) error {
+	logger.Info("mail", logrus.Fields{
+		"to":      msg.To,
+		"subject": msg.Subject,
+		"length":  len(msg.Body),
+	})
+	return nil
+}
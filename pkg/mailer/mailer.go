@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AsaHero/whereismycity/pkg/config"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New inits the configured mailer: smtp, file or log. Production only accepts smtp,
+// since the other drivers keep mail inside the deployment
+func New(cfg *config.Config) (Mailer, error) {
+	if cfg.Environment == config.Production && cfg.Mailer.Driver != "smtp" {
+		return nil, fmt.Errorf("mailer driver must be smtp in production, got %q", cfg.Mailer.Driver)
+	}
+
+	switch cfg.Mailer.Driver {
+	case "smtp":
+		if cfg.Mailer.SMTPHost == "" {
+			return nil, fmt.Errorf("smtp mailer requires a host")
+		}
+		return NewSMTP(cfg.Mailer.SMTPHost, cfg.Mailer.SMTPPort, cfg.Mailer.SMTPUsername, cfg.Mailer.SMTPPassword, cfg.Mailer.From), nil
+	case "file":
+		return NewFile(cfg.Mailer.Dir, cfg.Mailer.From)
+	case "log":
+		return NewLog(), nil
+	case "":
+		return nil, fmt.Errorf("mailer driver is required: smtp, file or log")
+	default:
+		return nil, fmt.Errorf("unknown mailer driver %q", cfg.Mailer.Driver)
+	}
+}
@@ -0,0 +1,26 @@
+package security
+
+const (
+	// APIKeyPrefix marks keys issued by this service so they are easy to recognize in leaked secrets
+	APIKeyPrefix = "wimc_"
+
+	// apiKeyDisplayLength is the number of leading characters kept in plaintext to identify a key
+	apiKeyDisplayLength = 12
+)
+
+// GenerateAPIKey returns a new random API key and its displayable prefix
+func GenerateAPIKey() (string, string, error) {
+	token, err := GenerateRandomToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	key := APIKeyPrefix + token
+
+	return key, key[:apiKeyDisplayLength], nil
+}
+
+// HashAPIKey hashes an API key for storage and lookup
+func HashAPIKey(key string) string {
+	return HashToken(key)
+}
@@ -14,10 +14,15 @@ type TokenClaims struct {
 	ExpiresAt int64
 	IssuedAt  int64
 	TokenID   string
+	// Email is set on email verification tokens
+	Email string
 }
 
-// GenerateTokenPair generates both access and refresh JWTs
-func GenerateTokenPair(userID string, secret string) (string, string, error) {
+// RefreshTokenTTL is how long a refresh token can be exchanged for a new pair
+const RefreshTokenTTL = time.Hour * 720 // 30 days
+
+// GenerateTokenPair generates both access and refresh JWTs, refreshTokenID becomes the refresh token jti
+func GenerateTokenPair(userID string, refreshTokenID string, secret string) (string, string, error) {
 	// Generate access token
 	accessToken, err := generateAccessToken(userID, secret)
 	if err != nil {
@@ -25,7 +30,7 @@ func GenerateTokenPair(userID string, secret string) (string, string, error) {
 	}
 
 	// Generate refresh token
-	refreshToken, err := generateRefreshToken(userID, secret)
+	refreshToken, err := generateRefreshToken(userID, refreshTokenID, secret)
 	if err != nil {
 		return "", "", fmt.Errorf("error generating refresh token: %w", err)
 	}
@@ -47,19 +52,36 @@ func generateAccessToken(userID string, secret string) (string, error) {
 }
 
 // generateRefreshToken creates a long-lived JWT token for obtaining new access tokens
-func generateRefreshToken(userID string, secret string) (string, error) {
+func generateRefreshToken(userID string, tokenID string, secret string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour * 720).Unix(), //30 days
+		"exp":     time.Now().Add(RefreshTokenTTL).Unix(),
 		"type":    "refresh",
 		"iat":     time.Now().Unix(),
-		// "jti":     uuid.New().String(),
+		"jti":     tokenID,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secret))
 }
 
+// GenerateEmailVerificationToken signs a token confirming the email of the user.
+// It is bound to the address, so changing the email invalidates tokens sent to the old one
+func GenerateEmailVerificationToken(userID, email string, ttl time.Duration, secret string) (string, error) {
+	return GenerateTokenWithClaims(jwt.MapClaims{
+		"user_id": userID,
+		"email":   email,
+		"exp":     time.Now().Add(ttl).Unix(),
+		"type":    "email_verification",
+		"iat":     time.Now().Unix(),
+	}, secret)
+}
+
+// ParseEmailVerificationToken is a convenience function for parsing email verification tokens
+func ParseEmailVerificationToken(tokenString string, secret string) (*TokenClaims, error) {
+	return ParseAndValidateToken(tokenString, secret, "email_verification")
+}
+
 // ParseAccessToken is a convenience function for parsing access tokens
 func ParseAccessToken(tokenString string, secret string) (*TokenClaims, error) {
 	return ParseAndValidateToken(tokenString, secret, "access")
@@ -131,6 +153,15 @@ func ParseAndValidateToken(tokenString string, secret string, expectedType strin
 		IssuedAt:  int64(claims["iat"].(float64)),
 	}
 
+	if email, ok := claims["email"].(string); ok {
+		tokenClaims.Email = email
+	}
+
+	// Access tokens and refresh tokens issued before rotation have no jti
+	if jti, ok := claims["jti"].(string); ok {
+		tokenClaims.TokenID = jti
+	}
+
 	return tokenClaims, nil
 }
 